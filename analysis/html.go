@@ -0,0 +1,192 @@
+package analysis
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// reportSentences is the number of hardest sentences of the HTML
+	// report, and reportWords that of longest words
+	reportSentences = 10
+	reportWords     = 10
+)
+
+var (
+	//go:embed templates/report.html
+	reportHTML     string
+	reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+)
+
+// difficulty is a band of sentence grade levels, colored alike in the
+// text of the HTML report.
+type difficulty struct {
+	Class string
+	Label string
+	// below is the grade level the band ends at
+	below float32
+}
+
+var difficulties = []difficulty{
+	{"easy", "below 6th grade", 6},
+	{"fair", "6th to 8th grade", 9},
+	{"hard", "9th to 12th grade", 13},
+	{"very-hard", "college", 0},
+}
+
+func difficultyOf(grade float32) difficulty {
+	for _, d := range difficulties[:len(difficulties)-1] {
+		if grade < d.below {
+			return d
+		}
+	}
+
+	return difficulties[len(difficulties)-1]
+}
+
+type htmlReport struct {
+	Name         string
+	Language     string
+	Counts       flesch.Counts
+	Ease         float32
+	Readability  string
+	Scores       []htmlScore
+	Charts       []htmlChart
+	Hardest      []HardSentence
+	LongestWords []htmlWord
+	Difficulties []difficulty
+	Paragraphs   []htmlParagraph
+}
+
+type htmlScore struct {
+	Name  string
+	Score float32
+	Band  string
+}
+
+type htmlChart struct {
+	Title string
+	// Source is the chart as a data URL
+	Source template.URL
+}
+
+type htmlWord struct {
+	Word       string
+	Characters int
+	Syllables  int
+}
+
+type htmlParagraph struct {
+	Heading   bool
+	Sentences []htmlSentence
+}
+
+type htmlSentence struct {
+	Text  string
+	Class string
+	Grade float32
+	Words int
+}
+
+// WriteHTMLReport writes a report of a document as a single HTML page
+// that needs nothing else to be viewed: every score with its reading
+// level, the charts of Build, the hardest sentences, the longest words
+// and the whole text with each sentence colored by its grade level.
+// Charts are embedded as SVG unless options render them as PNG; where
+// they would be saved is ignored.
+func WriteHTMLReport(w io.Writer, document flesch.Document, options ...Option) error {
+	format := newConfig(options).format
+	if format != PNG {
+		format = SVG
+	}
+	options = append(options[:len(options):len(options)], WithImageFormat(format), WithChartBytes())
+	report, err := Build(document, options...)
+	if err != nil {
+		return fmt.Errorf("building analysis: %w", err)
+	}
+
+	page := htmlReport{
+		Name:         document.Name(),
+		Language:     document.Language().Code,
+		Counts:       document.Counts(),
+		Ease:         document.Score(),
+		Readability:  document.ReadableScore(),
+		Hardest:      BuildHardestSentences(document, reportSentences, ByGrade).Sentences,
+		LongestWords: longestWords(document, reportWords),
+		Difficulties: difficulties,
+	}
+	for _, formula := range flesch.Formulas() {
+		if !formula.AppliesTo(document.Language()) {
+			continue
+		}
+		score := htmlScore{Name: formula.Name, Score: formula.Compute(document)}
+		if formula.Band != nil {
+			score.Band = formula.Band(score.Score)
+		}
+		page.Scores = append(page.Scores, score)
+	}
+	mediaType := "image/png"
+	if format == SVG {
+		mediaType = "image/svg+xml"
+	}
+	for _, chart := range []struct {
+		title string
+		data  []byte
+	}{
+		{"Readability timeline", report.TimelineAnalysis.Chart},
+		{"Sentence length distribution", report.SentenceLength.Chart},
+		{"Syllable distribution", report.SyllableAnalysis.Chart},
+		{"Syllable ratio", report.SyllableRatioAnalysis.Chart},
+		{"Word frequency by rank", report.Vocabulary.Chart},
+	} {
+		page.Charts = append(page.Charts, htmlChart{
+			Title:  chart.title,
+			Source: template.URL("data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(chart.data)),
+		})
+	}
+	for _, paragraph := range document.Paragraphs() {
+		p := htmlParagraph{Heading: paragraph.Heading}
+		for _, sentence := range paragraph.Sentences {
+			grade := sentence.Kincaid()
+			p.Sentences = append(p.Sentences, htmlSentence{
+				Text:  sentence.String(),
+				Class: difficultyOf(grade).Class,
+				Grade: grade,
+				Words: len(sentence.Words),
+			})
+		}
+		page.Paragraphs = append(page.Paragraphs, p)
+	}
+
+	if err := reportTemplate.Execute(w, page); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	return nil
+}
+
+// longestWords lists the n distinct words with the most characters, the
+// longest first, case folded.
+func longestWords(document flesch.Document, n int) []htmlWord {
+	var words []htmlWord
+	for _, word := range document.UniqueWords() {
+		words = append(words, htmlWord{strings.ToLower(word.String()), word.Characters(), word.Syllables()})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Characters != words[j].Characters {
+			return words[i].Characters > words[j].Characters
+		}
+		return words[i].Word < words[j].Word
+	})
+	if n < len(words) {
+		words = words[:n]
+	}
+
+	return words
+}
@@ -0,0 +1,30 @@
+package analysis_test
+
+import (
+	"bytes"
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTMLReportFormulas(t *testing.T) {
+	document, err := flesch.ParseString("El gato se sentó en la alfombra. Hacía calor.", "gato.txt", flesch.WithLanguage(flesch.Spanish))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := analysis.WriteHTMLReport(&buf, document); err != nil {
+		t.Fatalf("writing: %s", err)
+	}
+	page := buf.String()
+	for _, formula := range flesch.Formulas() {
+		shown := strings.Contains(page, "<td>"+formula.Name+"</td>")
+		if shown != formula.AppliesTo(flesch.Spanish) {
+			t.Errorf("%s: expected shown %t, got %t", formula.Name, formula.AppliesTo(flesch.Spanish), shown)
+		}
+	}
+	if strings.Contains(page, " </td>") {
+		t.Errorf("expected reading levels without padding")
+	}
+}
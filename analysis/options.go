@@ -0,0 +1,221 @@
+package analysis
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The names of the charts, as passed to a Naming or a chart writer.
+const (
+	ChartSyllableDistribution = "SyllableDistribution"
+	ChartSyllableRatio        = "SyllableRatio"
+	ChartReadabilityTimeline  = "ReadabilityTimeline"
+	ChartSentenceLength       = "SentenceLength"
+	ChartZipf                 = "Zipf"
+)
+
+// ImageFormat is a format charts are rendered in.
+type ImageFormat string
+
+const (
+	PNG ImageFormat = "png"
+	SVG ImageFormat = "svg"
+	PDF ImageFormat = "pdf"
+	EPS ImageFormat = "eps"
+)
+
+var imageFormats = []ImageFormat{PNG, SVG, PDF, EPS}
+
+// ParseImageFormat looks up an image format by name.
+func ParseImageFormat(name string) (ImageFormat, error) {
+	for _, format := range imageFormats {
+		if string(format) == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown image format %q, expected one of %v", name, imageFormats)
+}
+
+// Naming names the file of a document's chart.
+type Naming func(document, chart string, format ImageFormat) string
+
+// BaseNaming names a chart after the base name of its document, as in
+// "MobyDick.SyllableDistribution.png". Charts of documents with the same
+// base name in different directories overwrite each other.
+func BaseNaming(document, chart string, format ImageFormat) string {
+	_, filename := filepath.Split(document)
+	noExt := strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	return fmt.Sprintf("%s.%s.%s", noExt, chart, format)
+}
+
+// UniqueNaming names a chart after the base name of its document and a
+// hash of its directory, as in "MobyDick-1a2b3c4d.SyllableDistribution.png",
+// so that documents in different directories have charts of their own.
+func UniqueNaming(document, chart string, format ImageFormat) string {
+	dir := filepath.Dir(document)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	sum := sha1.Sum([]byte(dir))
+	_, filename := filepath.Split(document)
+	noExt := strings.TrimSuffix(filename, filepath.Ext(filename))
+
+	return fmt.Sprintf("%s-%s.%s.%s", noExt, hex.EncodeToString(sum[:4]), chart, format)
+}
+
+// Option configures how charts are rendered and where they go.
+type Option func(*config)
+
+type config struct {
+	directory     string
+	naming        Naming
+	format        ImageFormat
+	width, height vg.Length
+	dpi           int
+	writer        func(chart string) (io.Writer, error)
+	inMemory      bool
+}
+
+func newConfig(options []Option) config {
+	c := config{naming: BaseNaming, format: PNG, dpi: vgimg.DefaultDPI}
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// WithOutputDirectory saves charts in dir instead of
+// ~/.flesch-index-data. It is created if need be.
+func WithOutputDirectory(dir string) Option {
+	return func(c *config) {
+		c.directory = dir
+	}
+}
+
+// WithNaming names chart files with naming instead of BaseNaming.
+func WithNaming(naming Naming) Option {
+	return func(c *config) {
+		c.naming = naming
+	}
+}
+
+// WithImageFormat renders charts in format instead of PNG.
+func WithImageFormat(format ImageFormat) Option {
+	return func(c *config) {
+		c.format = format
+	}
+}
+
+// WithSize renders every chart at the given size instead of the size
+// of its kind.
+func WithSize(width, height vg.Length) Option {
+	return func(c *config) {
+		c.width, c.height = width, height
+	}
+}
+
+// WithDPI sets the resolution of PNG charts, 96 dots per inch by
+// default. Vector formats have no resolution.
+func WithDPI(dpi int) Option {
+	return func(c *config) {
+		c.dpi = dpi
+	}
+}
+
+// WithChartWriter writes each chart to the writer open returns for its
+// name instead of to a file, leaving ChartPath empty.
+func WithChartWriter(open func(chart string) (io.Writer, error)) Option {
+	return func(c *config) {
+		c.writer = open
+	}
+}
+
+// WithChartBytes keeps each chart in the Chart field of its analysis
+// instead of saving it to a file, leaving ChartPath empty.
+func WithChartBytes() Option {
+	return func(c *config) {
+		c.inMemory = true
+	}
+}
+
+// save renders a chart of a document, at the given size unless one is
+// configured, and returns the path of its file, or its bytes if they
+// are to be kept in memory.
+func (c config) save(p *plot.Plot, document, chart string, width, height vg.Length) (string, []byte, error) {
+	if c.width > 0 && c.height > 0 {
+		width, height = c.width, c.height
+	}
+	if c.dpi <= 0 {
+		return "", nil, fmt.Errorf("resolution of %d dpi", c.dpi)
+	}
+	var canvas io.WriterTo
+	if c.format == PNG {
+		img := vgimg.NewWith(vgimg.UseWH(width, height), vgimg.UseDPI(c.dpi))
+		p.Draw(draw.New(img))
+		canvas = vgimg.PngCanvas{Canvas: img}
+	} else {
+		formatted, err := draw.NewFormattedCanvas(width, height, string(c.format))
+		if err != nil {
+			return "", nil, err
+		}
+		p.Draw(draw.New(formatted))
+		canvas = formatted
+	}
+
+	switch {
+	case c.writer != nil:
+		w, err := c.writer(chart)
+		if err != nil {
+			return "", nil, fmt.Errorf("opening writer for %s chart: %w", chart, err)
+		}
+		if _, err := canvas.WriteTo(w); err != nil {
+			return "", nil, err
+		}
+		return "", nil, nil
+	case c.inMemory:
+		var buf bytes.Buffer
+		if _, err := canvas.WriteTo(&buf); err != nil {
+			return "", nil, err
+		}
+		return "", buf.Bytes(), nil
+	}
+
+	dir := c.directory
+	if dir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil, fmt.Errorf("getting user home directory: %w", err)
+		}
+		dir = filepath.Join(homeDir, ".flesch-index-data")
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", nil, fmt.Errorf("ensuring creation of output directory: %w", err)
+	}
+	chartPath := filepath.Join(dir, c.naming(document, chart, c.format))
+	f, err := os.Create(chartPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := canvas.WriteTo(f); err != nil {
+		f.Close()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		return "", nil, err
+	}
+
+	return chartPath, nil, nil
+}
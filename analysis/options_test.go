@@ -0,0 +1,146 @@
+package analysis_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func parseOrFail(t *testing.T, text, name string) flesch.Document {
+	t.Helper()
+	document, err := flesch.ParseString(text, name)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+
+	return document
+}
+
+func TestWithChartWriter(t *testing.T) {
+	document := parseOrFail(t, "The cat sat on the mat. It was warm.", "writer.txt")
+	charts := make(map[string]*bytes.Buffer)
+	open := func(chart string) (io.Writer, error) {
+		charts[chart] = new(bytes.Buffer)
+		return charts[chart], nil
+	}
+	report, err := analysis.Build(document, analysis.WithChartWriter(open))
+	if err != nil {
+		t.Fatalf("building: %s", err)
+	}
+	var names []string
+	for name, chart := range charts {
+		names = append(names, name)
+		if !bytes.HasPrefix(chart.Bytes(), []byte("\x89PNG")) {
+			t.Errorf("expected a PNG %s chart", name)
+		}
+	}
+	sort.Strings(names)
+	expected := []string{
+		analysis.ChartReadabilityTimeline,
+		analysis.ChartSentenceLength,
+		analysis.ChartSyllableDistribution,
+		analysis.ChartSyllableRatio,
+		analysis.ChartZipf,
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected charts %v, got %v", expected, names)
+	}
+	if report.SyllableAnalysis.ChartPath != "" || report.SyllableAnalysis.Chart != nil {
+		t.Errorf("expected neither a chart path nor bytes, got %q and %d bytes",
+			report.SyllableAnalysis.ChartPath, len(report.SyllableAnalysis.Chart))
+	}
+
+	failing := func(chart string) (io.Writer, error) {
+		return nil, errors.New("disk full")
+	}
+	if _, err := analysis.Build(document, analysis.WithChartWriter(failing)); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("expected the writer's error, got %v", err)
+	}
+}
+
+func TestWithChartBytes(t *testing.T) {
+	document := parseOrFail(t, "The cat sat on the mat. It was warm.", "bytes.txt")
+	tests := []struct {
+		format analysis.ImageFormat
+		magic  string
+	}{
+		{analysis.PNG, "\x89PNG"},
+		{analysis.SVG, "<?xml"},
+		{analysis.PDF, "%PDF"},
+		{analysis.EPS, "%%!PS-Adobe"},
+	}
+	for _, test := range tests {
+		syllables, err := analysis.BuildSyllableAnalysis(document, analysis.WithChartBytes(), analysis.WithImageFormat(test.format))
+		if err != nil {
+			t.Errorf("%s: %s", test.format, err)
+			continue
+		}
+		if syllables.ChartPath != "" || !bytes.HasPrefix(syllables.Chart, []byte(test.magic)) {
+			t.Errorf("%s: expected no path and a chart starting %q, got %q and %.10q",
+				test.format, test.magic, syllables.ChartPath, syllables.Chart)
+		}
+	}
+
+	if _, err := analysis.BuildSyllableAnalysis(document, analysis.WithChartBytes(), analysis.WithDPI(0)); err == nil {
+		t.Errorf("expected an error for a resolution of 0 dpi")
+	}
+}
+
+func TestWithOutputDirectory(t *testing.T) {
+	dir := t.TempDir()
+	document := parseOrFail(t, "The cat sat on the mat.", filepath.Join("docs", "cat.txt"))
+	syllables, err := analysis.BuildSyllableAnalysis(document,
+		analysis.WithOutputDirectory(filepath.Join(dir, "charts")),
+		analysis.WithImageFormat(analysis.SVG))
+	if err != nil {
+		t.Fatalf("building: %s", err)
+	}
+	expected := filepath.Join(dir, "charts", "cat.SyllableDistribution.svg")
+	if syllables.ChartPath != expected {
+		t.Errorf("expected chart path %s, got %s", expected, syllables.ChartPath)
+	}
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("expected the chart to be saved: %s", err)
+	}
+}
+
+func TestNaming(t *testing.T) {
+	if name := analysis.BaseNaming("docs/MobyDick.txt", analysis.ChartZipf, analysis.SVG); name != "MobyDick.Zipf.svg" {
+		t.Errorf("expected MobyDick.Zipf.svg, got %s", name)
+	}
+	a := analysis.UniqueNaming("a/MobyDick.txt", analysis.ChartZipf, analysis.PNG)
+	b := analysis.UniqueNaming("b/MobyDick.txt", analysis.ChartZipf, analysis.PNG)
+	if a == b || !strings.HasPrefix(a, "MobyDick-") || !strings.HasSuffix(a, ".Zipf.png") {
+		t.Errorf("expected distinct names of the form MobyDick-<hash>.Zipf.png, got %s and %s", a, b)
+	}
+	if again := analysis.UniqueNaming("a/MobyDick.md", analysis.ChartZipf, analysis.PNG); again != a {
+		t.Errorf("expected the same name for the same directory, got %s and %s", a, again)
+	}
+}
+
+func TestParseImageFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format analysis.ImageFormat
+		ok     bool
+	}{
+		{"png", analysis.PNG, true},
+		{"SVG", analysis.SVG, true},
+		{"pdf", analysis.PDF, true},
+		{"eps", analysis.EPS, true},
+		{"gif", "", false},
+	}
+	for _, test := range tests {
+		format, err := analysis.ParseImageFormat(test.name)
+		if format != test.format || (err == nil) != test.ok {
+			t.Errorf("%s: expected %q and ok %t, got %q and %v", test.name, test.format, test.ok, format, err)
+		}
+	}
+}
@@ -7,59 +7,25 @@ import (
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
-	"os"
-	"path"
-	"path/filepath"
 	"sort"
 	"strconv"
-	"strings"
 )
 
-func toPNGPath(originalFilename, chartName string) (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("getting user home directory: %w", err)
-	}
-	baseChartPath := path.Join(homeDir, ".flesch-index-data")
-	_, filename := filepath.Split(originalFilename)
-	noPath := strings.TrimSuffix(filename, path.Ext(filename))
-	newFilename := fmt.Sprintf("%s.%s.png", noPath, chartName)
-
-	return path.Join(baseChartPath, newFilename), nil
-}
-
-func ensureOutputDirectory() error {
-	exampleFilepath, err := toPNGPath("fake.ext", "none")
-	if err != nil {
-
-		return fmt.Errorf("determining output directory: %w", err)
-	}
-	folder, _ := path.Split(exampleFilepath)
-	err = os.MkdirAll(folder, os.ModePerm)
-	if err != nil {
-
-		return fmt.Errorf("ensuring creation of output directory: %w", err)
-	}
-
-	return nil
-}
-
 type SyllableDistributionAnalysis struct {
 	// Number of words that have a number of syllables
 	// e.g. [4]6 would mean there are 6 words with 4 syllables
 	SyllableDistribution map[int]int
 	KeyOrder             []int
-	ChartPath            string
+	// ChartPath is the file of the chart, unless it was written elsewhere
+	ChartPath string
+	// Chart is the rendered chart, with WithChartBytes
+	Chart []byte
 }
 
-func BuildSyllableAnalysis(document flesch.Document) (SyllableDistributionAnalysis, error) {
-	pngPath, err := toPNGPath(document.Name(), "SyllableDistribution")
-	if err != nil {
-		return SyllableDistributionAnalysis{}, fmt.Errorf("generating PNG path for chart: %w", err)
-	}
+func BuildSyllableAnalysis(document flesch.Document, options ...Option) (SyllableDistributionAnalysis, error) {
+	c := newConfig(options)
 	analysis := SyllableDistributionAnalysis{
 		SyllableDistribution: make(map[int]int),
-		ChartPath:            pngPath,
 	}
 	for _, word := range document.Words() {
 		syllables := word.Syllables()
@@ -107,8 +73,9 @@ func BuildSyllableAnalysis(document flesch.Document) (SyllableDistributionAnalys
 	p.Legend.Top = true
 	p.NominalX(wordCountLabels...)
 
-	if err := p.Save(5*vg.Inch, 3*vg.Inch, pngPath); err != nil {
-		return SyllableDistributionAnalysis{}, fmt.Errorf("saving chart png: %w", err)
+	analysis.ChartPath, analysis.Chart, err = c.save(p, document.Name(), ChartSyllableDistribution, 5*vg.Inch, 3*vg.Inch)
+	if err != nil {
+		return SyllableDistributionAnalysis{}, fmt.Errorf("saving chart: %w", err)
 	}
 
 	return analysis, nil
@@ -117,26 +84,45 @@ func BuildSyllableAnalysis(document flesch.Document) (SyllableDistributionAnalys
 type Report struct {
 	SyllableAnalysis      SyllableDistributionAnalysis
 	SyllableRatioAnalysis SyllableRatioAnalysis
+	TimelineAnalysis      TimelineAnalysis
+	SentenceLength        SentenceLengthAnalysis
+	Vocabulary            VocabularyAnalysis
 }
 
-func Build(document flesch.Document) (Report, error) {
-	// ensure output directory exists
-	if err := ensureOutputDirectory(); err != nil {
-		return Report{}, err
-	}
-
-	syllableAnalysis, err := BuildSyllableAnalysis(document)
+// Build builds every analysis of a document that has a chart, rendering
+// the charts as configured by options: by default, as PNG files in
+// ~/.flesch-index-data.
+func Build(document flesch.Document, options ...Option) (Report, error) {
+	syllableAnalysis, err := BuildSyllableAnalysis(document, options...)
 	if err != nil {
 		return Report{}, fmt.Errorf("building syllable distribution analysis: %w", err)
 	}
 
-	syllableRatioAnalysis, err := BuildSyllableRatioAnalysis(document)
+	syllableRatioAnalysis, err := BuildSyllableRatioAnalysis(document, options...)
 	if err != nil {
 		return Report{}, fmt.Errorf("building syllable ratio analysis: %w", err)
 	}
+
+	timelineAnalysis, err := BuildTimelineAnalysis(document, DefaultTimelineWindow, WindowSentences, options...)
+	if err != nil {
+		return Report{}, fmt.Errorf("building readability timeline analysis: %w", err)
+	}
+
+	sentenceLength, err := BuildSentenceLengthAnalysis(document, options...)
+	if err != nil {
+		return Report{}, fmt.Errorf("building sentence length analysis: %w", err)
+	}
+
+	vocabulary, err := BuildVocabularyAnalysis(document, options...)
+	if err != nil {
+		return Report{}, fmt.Errorf("building vocabulary analysis: %w", err)
+	}
 	return Report{
 		SyllableAnalysis:      syllableAnalysis,
 		SyllableRatioAnalysis: syllableRatioAnalysis,
+		TimelineAnalysis:      timelineAnalysis,
+		SentenceLength:        sentenceLength,
+		Vocabulary:            vocabulary,
 	}, nil
 }
 
@@ -154,18 +140,17 @@ func (ratio charactersToSyllables) Ratio() float64 {
 type SyllableRatioAnalysis struct {
 	SyllableRatio map[string]float64
 	KeyOrder      []string
-	ChartPath     string
+	// ChartPath is the file of the chart, unless it was written elsewhere
+	ChartPath string
+	// Chart is the rendered chart, with WithChartBytes
+	Chart []byte
 }
 
-func BuildSyllableRatioAnalysis(document flesch.Document) (SyllableRatioAnalysis, error) {
+func BuildSyllableRatioAnalysis(document flesch.Document, options ...Option) (SyllableRatioAnalysis, error) {
+	c := newConfig(options)
 	const numberCharted = 8
-	pngPath, err := toPNGPath(document.Name(), "SyllableRatio")
-	if err != nil {
-		return SyllableRatioAnalysis{}, fmt.Errorf("generating PNG path for chart: %w", err)
-	}
 	analysis := SyllableRatioAnalysis{
 		SyllableRatio: make(map[string]float64),
-		ChartPath:     pngPath,
 	}
 	words := document.UniqueWords()
 	var keyOrder []charactersToSyllables
@@ -187,9 +172,6 @@ func BuildSyllableRatioAnalysis(document flesch.Document) (SyllableRatioAnalysis
 	}
 	var ratios []float64
 	for _, word := range analysis.KeyOrder {
-		if len(ratios) >= numberCharted {
-			break
-		}
 		ratios = append(ratios, analysis.SyllableRatio[word])
 	}
 
@@ -198,7 +180,7 @@ func BuildSyllableRatioAnalysis(document flesch.Document) (SyllableRatioAnalysis
 	if err != nil {
 		return SyllableRatioAnalysis{}, fmt.Errorf("creating a new plot: %w", err)
 	}
-	p.Title.Text = fmt.Sprintf("Top %d Syllable Ratio (characters to syllables)", numberCharted)
+	p.Title.Text = fmt.Sprintf("Top %d Syllable Ratio (characters to syllables)", len(analysis.KeyOrder))
 	p.Y.Label.Text = "Ratio"
 	p.X.Label.Text = "Words"
 
@@ -214,10 +196,11 @@ func BuildSyllableRatioAnalysis(document flesch.Document) (SyllableRatioAnalysis
 
 	p.Add(bar)
 	p.Legend.Top = true
-	p.NominalX(analysis.KeyOrder[:numberCharted]...)
+	p.NominalX(analysis.KeyOrder...)
 
-	if err := p.Save(5*vg.Inch, 3*vg.Inch, pngPath); err != nil {
-		return SyllableRatioAnalysis{}, fmt.Errorf("saving chart png: %w", err)
+	analysis.ChartPath, analysis.Chart, err = c.save(p, document.Name(), ChartSyllableRatio, 5*vg.Inch, 3*vg.Inch)
+	if err != nil {
+		return SyllableRatioAnalysis{}, fmt.Errorf("saving chart: %w", err)
 	}
 
 	return analysis, nil
@@ -0,0 +1,33 @@
+package analysis_test
+
+import (
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"testing"
+)
+
+func TestBuildTinyDocument(t *testing.T) {
+	// fewer distinct words than the syllable ratio chart has bars
+	document, err := flesch.ParseString("Hello there friend.", "tiny")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	report, err := analysis.Build(document, analysis.WithChartBytes())
+	if err != nil {
+		t.Fatalf("building: %s", err)
+	}
+	if len(report.SyllableRatioAnalysis.KeyOrder) != 3 {
+		t.Errorf("expected 3 words charted, got %v", report.SyllableRatioAnalysis.KeyOrder)
+	}
+	for name, chart := range map[string][]byte{
+		"syllable distribution": report.SyllableAnalysis.Chart,
+		"syllable ratio":        report.SyllableRatioAnalysis.Chart,
+		"timeline":              report.TimelineAnalysis.Chart,
+		"sentence length":       report.SentenceLength.Chart,
+		"zipf":                  report.Vocabulary.Chart,
+	} {
+		if len(chart) == 0 {
+			t.Errorf("expected a %s chart", name)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// lengthBucketWidth is the width of a bar of the sentence length
+	// chart, in words, and lengthBuckets the number of bars before the
+	// last, which holds every longer sentence
+	lengthBucketWidth = 5
+	lengthBuckets     = 12
+)
+
+// Percentiles are the percentiles of sentence length reported.
+var Percentiles = []int{10, 25, 50, 75, 90, 95, 99}
+
+// LengthOutlier is a sentence far longer, or shorter, than most.
+type LengthOutlier struct {
+	Words int
+	Start flesch.Position
+	// Text is the sentence on a single line
+	Text string
+}
+
+// SentenceLengthAnalysis describes how many words the sentences of a
+// document have. The average sentence length is half of the Flesch
+// formulas.
+type SentenceLengthAnalysis struct {
+	// Number of sentences that have a number of words
+	// e.g. [12]3 would mean there are 3 sentences of 12 words
+	LengthDistribution map[int]int
+	KeyOrder           []int
+	Mean               float64
+	Median             float64
+	StandardDeviation  float64
+	// Percentiles by percentile, e.g. [90]28 would mean 90% of sentences
+	// have at most 28 words
+	Percentiles map[int]float64
+	// Outliers are the sentences more than one and a half interquartile
+	// ranges outside the middle half of lengths, the longest first
+	Outliers []LengthOutlier
+	// ChartPath is the file of the chart, unless it was written elsewhere
+	ChartPath string
+	// Chart is the rendered chart, with WithChartBytes
+	Chart []byte
+}
+
+func BuildSentenceLengthAnalysis(document flesch.Document, options ...Option) (SentenceLengthAnalysis, error) {
+	c := newConfig(options)
+	if len(document.Sentences) == 0 {
+		return SentenceLengthAnalysis{}, fmt.Errorf("no sentences to chart")
+	}
+	analysis := SentenceLengthAnalysis{
+		LengthDistribution: make(map[int]int),
+		Percentiles:        make(map[int]float64),
+	}
+	lengths := make([]float64, len(document.Sentences))
+	var sum float64
+	for i, sentence := range document.Sentences {
+		analysis.LengthDistribution[len(sentence.Words)]++
+		lengths[i] = float64(len(sentence.Words))
+		sum += lengths[i]
+	}
+	for length := range analysis.LengthDistribution {
+		analysis.KeyOrder = append(analysis.KeyOrder, length)
+	}
+	sort.Ints(analysis.KeyOrder)
+
+	analysis.Mean = sum / float64(len(lengths))
+	var squares float64
+	for _, length := range lengths {
+		squares += (length - analysis.Mean) * (length - analysis.Mean)
+	}
+	analysis.StandardDeviation = math.Sqrt(squares / float64(len(lengths)))
+	sort.Float64s(lengths)
+	for _, p := range Percentiles {
+		analysis.Percentiles[p] = percentile(lengths, p)
+	}
+	analysis.Median = percentile(lengths, 50)
+	q1, q3 := percentile(lengths, 25), percentile(lengths, 75)
+	low, high := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+	for _, sentence := range document.Sentences {
+		if words := float64(len(sentence.Words)); words < low || words > high {
+			analysis.Outliers = append(analysis.Outliers, LengthOutlier{
+				Words: len(sentence.Words),
+				Start: sentence.StartPos,
+				Text:  strings.Join(strings.Fields(sentence.String()), " "),
+			})
+		}
+	}
+	sort.SliceStable(analysis.Outliers, func(i, j int) bool {
+		return analysis.Outliers[i].Words > analysis.Outliers[j].Words
+	})
+
+	p, err := plot.New()
+	if err != nil {
+		return SentenceLengthAnalysis{}, fmt.Errorf("creating a new plot: %w", err)
+	}
+	p.Title.Text = "Sentence Length Distribution"
+	p.Y.Label.Text = "Sentence Count"
+	p.X.Label.Text = "Words"
+
+	w := vg.Points(20)
+
+	// Group the lengths into buckets, the last holding all the longest
+	sentenceCounts := make([]float64, lengthBuckets+1)
+	var bucketLabels []string
+	for bucket := 0; bucket < lengthBuckets; bucket++ {
+		bucketLabels = append(bucketLabels, fmt.Sprintf("%d-%d", bucket*lengthBucketWidth+1, (bucket+1)*lengthBucketWidth))
+	}
+	bucketLabels = append(bucketLabels, fmt.Sprintf("%d+", lengthBuckets*lengthBucketWidth+1))
+	for length, count := range analysis.LengthDistribution {
+		bucket := (length - 1) / lengthBucketWidth
+		if bucket < 0 {
+			bucket = 0
+		}
+		if bucket > lengthBuckets {
+			bucket = lengthBuckets
+		}
+		sentenceCounts[bucket] += float64(count)
+	}
+
+	barValue := plotter.Values(sentenceCounts)
+	bar, err := plotter.NewBarChart(barValue, w)
+	if err != nil {
+		return SentenceLengthAnalysis{}, fmt.Errorf("creating bar with values %v: %w", []float64(barValue), err)
+	}
+	bar.LineStyle.Width = vg.Length(0)
+	bar.Color = plotutil.Color(2)
+
+	p.Add(bar)
+	p.Legend.Top = true
+	p.NominalX(bucketLabels...)
+
+	analysis.ChartPath, analysis.Chart, err = c.save(p, document.Name(), ChartSentenceLength, 6*vg.Inch, 3*vg.Inch)
+	if err != nil {
+		return SentenceLengthAnalysis{}, fmt.Errorf("saving chart: %w", err)
+	}
+
+	return analysis, nil
+}
+
+// percentile interpolates the p-th percentile of sorted values.
+func percentile(sorted []float64, p int) float64 {
+	rank := float64(p) / 100 * float64(len(sorted)-1)
+	below := int(math.Floor(rank))
+	if below+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+
+	return sorted[below] + (rank-float64(below))*(sorted[below+1]-sorted[below])
+}
@@ -0,0 +1,91 @@
+package analysis_test
+
+import (
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sentencesOf writes a sentence of each length in words.
+func sentencesOf(lengths ...int) string {
+	var sentences []string
+	for _, length := range lengths {
+		sentences = append(sentences, strings.TrimSpace(strings.Repeat("cat ", length))+".")
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+func TestBuildSentenceLengthAnalysis(t *testing.T) {
+	tests := []struct {
+		name        string
+		lengths     []int
+		percentiles map[int]float64
+		mean        float64
+		deviation   float64
+		outliers    []int
+	}{
+		{
+			name:        "evenly spread",
+			lengths:     []int{1, 2, 3, 4, 5},
+			percentiles: map[int]float64{10: 1.4, 25: 2, 50: 3, 75: 4, 90: 4.6, 95: 4.8, 99: 4.96},
+			mean:        3,
+			deviation:   math.Sqrt2,
+		},
+		{
+			name:        "one long",
+			lengths:     []int{2, 2, 2, 2, 20},
+			percentiles: map[int]float64{25: 2, 50: 2, 75: 2},
+			mean:        5.6,
+			deviation:   7.2,
+			outliers:    []int{20},
+		},
+		{
+			name:        "long and short",
+			lengths:     []int{10, 10, 1, 10, 30, 10},
+			percentiles: map[int]float64{25: 10, 50: 10, 75: 10},
+			mean:        71.0 / 6,
+			deviation:   math.Sqrt(2765.0 / 36),
+			outliers:    []int{30, 1},
+		},
+		{
+			name:        "one sentence",
+			lengths:     []int{7},
+			percentiles: map[int]float64{10: 7, 50: 7, 99: 7},
+			mean:        7,
+		},
+	}
+	for _, test := range tests {
+		document, err := flesch.ParseString(sentencesOf(test.lengths...), test.name)
+		if err != nil {
+			t.Fatalf("%s: parsing: %s", test.name, err)
+		}
+		lengths, err := analysis.BuildSentenceLengthAnalysis(document, analysis.WithChartBytes())
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		for p, expected := range test.percentiles {
+			if got := lengths.Percentiles[p]; math.Abs(got-expected) > 1e-9 {
+				t.Errorf("%s: expected percentile %d of %v, got %v", test.name, p, expected, got)
+			}
+		}
+		if lengths.Median != lengths.Percentiles[50] {
+			t.Errorf("%s: expected the median %v to be percentile 50, %v", test.name, lengths.Median, lengths.Percentiles[50])
+		}
+		if math.Abs(lengths.Mean-test.mean) > 1e-9 || math.Abs(lengths.StandardDeviation-test.deviation) > 1e-9 {
+			t.Errorf("%s: expected mean %v and deviation %v, got %v and %v",
+				test.name, test.mean, test.deviation, lengths.Mean, lengths.StandardDeviation)
+		}
+		var outliers []int
+		for _, outlier := range lengths.Outliers {
+			outliers = append(outliers, outlier.Words)
+		}
+		if !reflect.DeepEqual(outliers, test.outliers) {
+			t.Errorf("%s: expected outliers of %v words, got %v", test.name, test.outliers, outliers)
+		}
+	}
+}
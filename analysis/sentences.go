@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"sort"
+	"strings"
+)
+
+// SentenceOrder is what sentences are ranked by to find the hardest.
+type SentenceOrder string
+
+const (
+	ByGrade         SentenceOrder = "grade"
+	ByWords         SentenceOrder = "words"
+	ByPolysyllables SentenceOrder = "polysyllables"
+)
+
+var sentenceOrders = []SentenceOrder{ByGrade, ByWords, ByPolysyllables}
+
+// ParseSentenceOrder looks up an order by name.
+func ParseSentenceOrder(name string) (SentenceOrder, error) {
+	for _, order := range sentenceOrders {
+		if string(order) == name {
+			return order, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown sentence order %q, expected one of %v", name, sentenceOrders)
+}
+
+// HardSentence is a sentence worth rewriting, with what makes it hard.
+type HardSentence struct {
+	// Text is the sentence as it appears in the document
+	Text          string
+	Start, End    flesch.Position
+	Grade         float32
+	Ease          float32
+	Words         int
+	Polysyllables int
+}
+
+// HardestSentencesAnalysis lists the hardest sentences of a document,
+// the hardest first.
+type HardestSentencesAnalysis struct {
+	Order     SentenceOrder
+	Sentences []HardSentence
+}
+
+// BuildHardestSentences ranks the sentences of a document by the given
+// order, breaking ties by grade level, then word count, then
+// polysyllable count, and keeps the first n, none if n is negative.
+func BuildHardestSentences(document flesch.Document, n int, order SentenceOrder) HardestSentencesAnalysis {
+	sentences := make([]HardSentence, len(document.Sentences))
+	for i, sentence := range document.Sentences {
+		counts := sentence.Counts()
+		sentences[i] = HardSentence{
+			Text:          sentence.String(),
+			Start:         sentence.StartPos,
+			End:           sentence.EndPos,
+			Grade:         sentence.Kincaid(),
+			Ease:          sentence.Score(),
+			Words:         counts.Words,
+			Polysyllables: counts.Polysyllables,
+		}
+	}
+	keys := func(s HardSentence) []float32 {
+		grade, words, polysyllables := s.Grade, float32(s.Words), float32(s.Polysyllables)
+		switch order {
+		case ByWords:
+			return []float32{words, grade, polysyllables}
+		case ByPolysyllables:
+			return []float32{polysyllables, grade, words}
+		}
+		return []float32{grade, words, polysyllables}
+	}
+	sort.SliceStable(sentences, func(i, j int) bool {
+		a, b := keys(sentences[i]), keys(sentences[j])
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return false
+	})
+	if n < 0 {
+		n = 0
+	}
+	if n < len(sentences) {
+		sentences = sentences[:n]
+	}
+
+	return HardestSentencesAnalysis{Order: order, Sentences: sentences}
+}
+
+// Excerpt is the text of the sentence on a single line.
+func (s HardSentence) Excerpt() string {
+	return strings.Join(strings.Fields(s.Text), " ")
+}
@@ -0,0 +1,32 @@
+package analysis_test
+
+import (
+	"github.com/PaluMacil/flesch-index/analysis"
+	"reflect"
+	"testing"
+)
+
+func TestBuildHardestSentences(t *testing.T) {
+	document := parseOrFail(t, "The cat sat. Unquestionably, the committee deliberated interminably. It rained all day and all night.", "hardest")
+	tests := []struct {
+		n     int
+		order analysis.SentenceOrder
+		words []int
+	}{
+		{-1, analysis.ByGrade, nil},
+		{0, analysis.ByGrade, nil},
+		{1, analysis.ByGrade, []int{5}},
+		{2, analysis.ByWords, []int{7, 5}},
+		{10, analysis.ByWords, []int{7, 5, 3}},
+	}
+	for _, test := range tests {
+		hardest := analysis.BuildHardestSentences(document, test.n, test.order)
+		var words []int
+		for _, sentence := range hardest.Sentences {
+			words = append(words, sentence.Words)
+		}
+		if !reflect.DeepEqual(words, test.words) {
+			t.Errorf("n of %d by %s: expected sentences of %v words, got %v", test.n, test.order, test.words, words)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// WindowUnit is what the window of a timeline is measured in.
+type WindowUnit string
+
+const (
+	WindowSentences WindowUnit = "sentences"
+	WindowWords     WindowUnit = "words"
+)
+
+// DefaultTimelineWindow is the window of the timeline of Build, in
+// sentences.
+const DefaultTimelineWindow = 10
+
+// TimelinePoint is the readability of a window of sentences.
+type TimelinePoint struct {
+	// FirstSentence is the index of the window's first sentence, and
+	// FirstWord that of its first word, within the document
+	FirstSentence int
+	FirstWord     int
+	Sentences     int
+	Words         int
+	Start         flesch.Position
+	Ease          float64
+	Grade         float64
+}
+
+// TimelineAnalysis follows readability through a document, to show
+// where it gets harder.
+type TimelineAnalysis struct {
+	Window int
+	Unit   WindowUnit
+	Points []TimelinePoint
+	// ChartPath is the file of the chart, unless it was written elsewhere
+	ChartPath string
+	// Chart is the rendered chart, with WithChartBytes
+	Chart []byte
+}
+
+// BuildTimelineAnalysis computes the reading ease and grade level of a
+// window sliding a sentence at a time across a document. A window of
+// words is made of whole sentences, as few as add up to window words.
+// A document shorter than the window is a single window.
+func BuildTimelineAnalysis(document flesch.Document, window int, unit WindowUnit, options ...Option) (TimelineAnalysis, error) {
+	c := newConfig(options)
+	if window < 1 {
+		return TimelineAnalysis{}, fmt.Errorf("window of %d %s", window, unit)
+	}
+	if unit != WindowSentences && unit != WindowWords {
+		return TimelineAnalysis{}, fmt.Errorf("unknown window unit %q", unit)
+	}
+	analysis := TimelineAnalysis{
+		Window: window,
+		Unit:   unit,
+	}
+
+	// totals of the sentences before each sentence, so that those of a
+	// window are a difference
+	sentences := document.Sentences
+	words := make([]int, len(sentences)+1)
+	syllables := make([]int, len(sentences)+1)
+	for i, sentence := range sentences {
+		words[i+1] = words[i] + len(sentence.Words)
+		syllables[i+1] = syllables[i] + sentence.Syllables()
+	}
+	for first := 0; first < len(sentences); first++ {
+		end := first + window
+		if unit == WindowWords {
+			end = first + 1
+			for end < len(sentences) && words[end]-words[first] < window {
+				end++
+			}
+		}
+		if end > len(sentences) || unit == WindowWords && words[end]-words[first] < window {
+			// only a document shorter than the window ends in a short one
+			if first > 0 {
+				break
+			}
+			end = len(sentences)
+		}
+		counts := flesch.Counts{
+			Sentences: end - first,
+			Words:     words[end] - words[first],
+			Syllables: syllables[end] - syllables[first],
+		}
+		analysis.Points = append(analysis.Points, TimelinePoint{
+			FirstSentence: first,
+			FirstWord:     words[first],
+			Sentences:     counts.Sentences,
+			Words:         counts.Words,
+			Start:         sentences[first].StartPos,
+			Ease:          float64(document.Language().Ease(counts)),
+			Grade:         float64(counts.Kincaid()),
+		})
+	}
+	if len(analysis.Points) == 0 {
+		return TimelineAnalysis{}, fmt.Errorf("no sentences to chart")
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		return TimelineAnalysis{}, fmt.Errorf("creating a new plot: %w", err)
+	}
+	p.Title.Text = fmt.Sprintf("Readability Over %d %s", window, unit)
+	p.Y.Label.Text = "Score"
+	p.X.Label.Text = "Words Into Document"
+
+	ease := make(plotter.XYs, len(analysis.Points))
+	grade := make(plotter.XYs, len(analysis.Points))
+	for i, point := range analysis.Points {
+		ease[i].X, ease[i].Y = float64(point.FirstWord), point.Ease
+		grade[i].X, grade[i].Y = float64(point.FirstWord), point.Grade
+	}
+	if err := plotutil.AddLines(p, "Reading Ease", ease, "Grade Level", grade); err != nil {
+		return TimelineAnalysis{}, fmt.Errorf("adding lines: %w", err)
+	}
+	p.Legend.Top = true
+
+	analysis.ChartPath, analysis.Chart, err = c.save(p, document.Name(), ChartReadabilityTimeline, 8*vg.Inch, 3*vg.Inch)
+	if err != nil {
+		return TimelineAnalysis{}, fmt.Errorf("saving chart: %w", err)
+	}
+
+	return analysis, nil
+}
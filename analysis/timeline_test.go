@@ -0,0 +1,73 @@
+package analysis_test
+
+import (
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"reflect"
+	"testing"
+)
+
+func TestBuildTimelineAnalysis(t *testing.T) {
+	// sentences of 3, 2, 1 and 4 words
+	document, err := flesch.ParseString("One two three. Four five. Six. Seven eight nine ten.", "timeline")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	tests := []struct {
+		window    int
+		unit      analysis.WindowUnit
+		sentences []int
+		words     []int
+	}{
+		{1, analysis.WindowSentences, []int{1, 1, 1, 1}, []int{3, 2, 1, 4}},
+		{2, analysis.WindowSentences, []int{2, 2, 2}, []int{5, 3, 5}},
+		{4, analysis.WindowSentences, []int{4}, []int{10}},
+		{10, analysis.WindowSentences, []int{4}, []int{10}},
+		{3, analysis.WindowWords, []int{1, 2, 2, 1}, []int{3, 3, 5, 4}},
+		{5, analysis.WindowWords, []int{2, 3, 2}, []int{5, 7, 5}},
+		{20, analysis.WindowWords, []int{4}, []int{10}},
+	}
+	for _, test := range tests {
+		timeline, err := analysis.BuildTimelineAnalysis(document, test.window, test.unit, analysis.WithChartBytes())
+		if err != nil {
+			t.Errorf("window of %d %s: %s", test.window, test.unit, err)
+			continue
+		}
+		var sentences, words []int
+		for _, point := range timeline.Points {
+			sentences = append(sentences, point.Sentences)
+			words = append(words, point.Words)
+		}
+		if !reflect.DeepEqual(sentences, test.sentences) || !reflect.DeepEqual(words, test.words) {
+			t.Errorf("window of %d %s: expected sentences %v and words %v, got %v and %v",
+				test.window, test.unit, test.sentences, test.words, sentences, words)
+		}
+	}
+}
+
+func TestBuildTimelineAnalysisErrors(t *testing.T) {
+	document, err := flesch.ParseString("One two three.", "timeline")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	empty, err := flesch.ParseString("", "empty")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	tests := []struct {
+		name     string
+		document flesch.Document
+		window   int
+		unit     analysis.WindowUnit
+	}{
+		{"no window", document, 0, analysis.WindowSentences},
+		{"negative window", document, -1, analysis.WindowWords},
+		{"unknown unit", document, 5, "lines"},
+		{"no sentences", empty, 5, analysis.WindowSentences},
+	}
+	for _, test := range tests {
+		if _, err := analysis.BuildTimelineAnalysis(test.document, test.window, test.unit, analysis.WithChartBytes()); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// mtldThreshold is the type-token ratio at which MTLD ends a factor
+	mtldThreshold = 0.72
+	// hddSample is the number of tokens of the random samples of HD-D
+	hddSample = 42
+)
+
+// WordFrequency is the number of times a word occurs in a document.
+type WordFrequency struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// VocabularyAnalysis measures the richness of a document's vocabulary.
+// Words are case folded, so "The" and "the" are the same type.
+type VocabularyAnalysis struct {
+	// Frequencies of each word, the most frequent first
+	Frequencies []WordFrequency
+	// Tokens is the number of words, and Types that of distinct words
+	Tokens         int
+	Types          int
+	TypeTokenRatio float64
+	// MTLD is the measure of textual lexical diversity: the mean length
+	// of the runs of words whose type-token ratio stays above 0.72
+	MTLD float64
+	// HDD is the mean chance of each type to be drawn in a random sample
+	// of 42 words, which like the type-token ratio is between 0 and 1
+	HDD float64
+	// HapaxLegomena are the words that occur once, and DisLegomena those
+	// that occur twice
+	HapaxLegomena int
+	DisLegomena   int
+	// ChartPath is the file of the chart, unless it was written elsewhere
+	ChartPath string
+	// Chart is the rendered chart, with WithChartBytes
+	Chart []byte
+}
+
+func BuildVocabularyAnalysis(document flesch.Document, options ...Option) (VocabularyAnalysis, error) {
+	c := newConfig(options)
+	var tokens []string
+	counts := make(map[string]int)
+	for _, word := range document.Words() {
+		token := strings.ToLower(word.String())
+		tokens = append(tokens, token)
+		counts[token]++
+	}
+	if len(tokens) == 0 {
+		return VocabularyAnalysis{}, fmt.Errorf("no words to chart")
+	}
+	analysis := VocabularyAnalysis{
+		Tokens:         len(tokens),
+		Types:          len(counts),
+		TypeTokenRatio: float64(len(counts)) / float64(len(tokens)),
+		MTLD:           mtld(tokens),
+		HDD:            hdd(counts, len(tokens)),
+	}
+	for word, count := range counts {
+		analysis.Frequencies = append(analysis.Frequencies, WordFrequency{word, count})
+		switch count {
+		case 1:
+			analysis.HapaxLegomena++
+		case 2:
+			analysis.DisLegomena++
+		}
+	}
+	sort.Slice(analysis.Frequencies, func(i, j int) bool {
+		a, b := analysis.Frequencies[i], analysis.Frequencies[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Word < b.Word
+	})
+
+	p, err := plot.New()
+	if err != nil {
+		return VocabularyAnalysis{}, fmt.Errorf("creating a new plot: %w", err)
+	}
+	p.Title.Text = "Word Frequency by Rank (Zipf)"
+	p.Y.Label.Text = "Frequency"
+	p.X.Label.Text = "Rank"
+	p.X.Scale, p.Y.Scale = plot.LogScale{}, plot.LogScale{}
+	p.X.Tick.Marker, p.Y.Tick.Marker = plot.LogTicks{}, plot.LogTicks{}
+
+	ranks := make(plotter.XYs, len(analysis.Frequencies))
+	for i, frequency := range analysis.Frequencies {
+		ranks[i].X, ranks[i].Y = float64(i+1), float64(frequency.Count)
+	}
+	scatter, err := plotter.NewScatter(ranks)
+	if err != nil {
+		return VocabularyAnalysis{}, fmt.Errorf("creating scatter of %d words: %w", len(ranks), err)
+	}
+	scatter.GlyphStyle.Color = plotutil.Color(3)
+	scatter.GlyphStyle.Radius = vg.Points(1.5)
+
+	p.Add(scatter)
+	// padding a range of a single value would take it to zero, which a
+	// log scale cannot show, as when every word occurs once
+	p.X.Min, p.X.Max = 0.5, float64(len(ranks))*2
+	p.Y.Min, p.Y.Max = 0.5, float64(analysis.Frequencies[0].Count)*2
+
+	analysis.ChartPath, analysis.Chart, err = c.save(p, document.Name(), ChartZipf, 5*vg.Inch, 4*vg.Inch)
+	if err != nil {
+		return VocabularyAnalysis{}, fmt.Errorf("saving chart: %w", err)
+	}
+
+	return analysis, nil
+}
+
+// mtld averages the measure of textual lexical diversity of the tokens
+// read forwards and backwards.
+func mtld(tokens []string) float64 {
+	backwards := make([]string, len(tokens))
+	for i, token := range tokens {
+		backwards[len(tokens)-1-i] = token
+	}
+
+	return (mtldFactors(tokens) + mtldFactors(backwards)) / 2
+}
+
+// mtldFactors divides the number of tokens by the number of factors:
+// runs of tokens ending once their type-token ratio falls to the
+// threshold, and a part of a factor for the run left at the end. Text
+// too varied to finish a factor scores its length.
+func mtldFactors(tokens []string) float64 {
+	var factors float64
+	types := make(map[string]bool)
+	var run int
+	for _, token := range tokens {
+		types[token] = true
+		run++
+		if float64(len(types))/float64(run) <= mtldThreshold {
+			factors++
+			types = make(map[string]bool)
+			run = 0
+		}
+	}
+	if run > 0 {
+		ratio := float64(len(types)) / float64(run)
+		factors += (1 - ratio) / (1 - mtldThreshold)
+	}
+	if factors == 0 {
+		return float64(len(tokens))
+	}
+
+	return float64(len(tokens)) / factors
+}
+
+// hdd sums the chance of each type to occur at least once in a sample
+// of tokens drawn without replacement, following the hypergeometric
+// distribution, and divides it by the sample size. Documents shorter
+// than the sample are sampled whole.
+func hdd(counts map[string]int, tokens int) float64 {
+	sample := hddSample
+	if tokens < sample {
+		sample = tokens
+	}
+	var sum float64
+	for _, count := range counts {
+		// the chance of the sample missing the type altogether
+		var missed float64
+		if tokens-count >= sample {
+			missed = math.Exp(logChoose(tokens-count, sample) - logChoose(tokens, sample))
+		}
+		sum += 1 - missed
+	}
+
+	return sum / float64(sample)
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+
+	return a - b - c
+}
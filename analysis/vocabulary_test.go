@@ -0,0 +1,62 @@
+package analysis_test
+
+import (
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestVocabularyAllHapax(t *testing.T) {
+	// every word occurs once, so the frequencies of the Zipf chart are a
+	// single value
+	document, err := flesch.ParseString("Hello there friend. How are you?", "hapax")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	vocabulary, err := analysis.BuildVocabularyAnalysis(document, analysis.WithChartBytes())
+	if err != nil {
+		t.Fatalf("building: %s", err)
+	}
+	if vocabulary.Tokens != 6 || vocabulary.Types != 6 || vocabulary.HapaxLegomena != 6 {
+		t.Errorf("expected 6 tokens, types and hapax legomena, got %d, %d and %d",
+			vocabulary.Tokens, vocabulary.Types, vocabulary.HapaxLegomena)
+	}
+	if len(vocabulary.Chart) == 0 {
+		t.Errorf("expected a chart")
+	}
+}
+
+func TestVocabularyDiversity(t *testing.T) {
+	tests := []struct {
+		text string
+		mtld float64
+		hdd  float64
+	}{
+		// no factor ends, so MTLD is the length
+		{"Hello there friend. How are you?", 6, 1},
+		// a factor every two tokens
+		{"Cat cat cat cat.", 2, 0.25},
+		{"Cat dog cat dog cat dog.", 3, 1.0 / 3},
+		// a part of a factor of a ratio of 3/4
+		{"Cat dog bird cat.", 4 / (0.25 / (1 - 0.72)), 0.75},
+		// longer than the sample, dog is missed by C(49,42)/C(50,42) of samples
+		{strings.Repeat("cat ", 49) + "dog.", 50.0 / 24, (1 + 1 - 8.0/50) / 42},
+	}
+	for _, test := range tests {
+		document, err := flesch.ParseString(test.text, "diversity")
+		if err != nil {
+			t.Fatalf("parsing: %s", err)
+		}
+		vocabulary, err := analysis.BuildVocabularyAnalysis(document, analysis.WithChartBytes())
+		if err != nil {
+			t.Errorf("%q: %s", test.text, err)
+			continue
+		}
+		if math.Abs(vocabulary.MTLD-test.mtld) > 1e-9 || math.Abs(vocabulary.HDD-test.hdd) > 1e-9 {
+			t.Errorf("%q: expected MTLD %v and HD-D %v, got %v and %v",
+				test.text, test.mtld, test.hdd, vocabulary.MTLD, vocabulary.HDD)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// fileFlags are the flags that choose which files of directories are
+// scored, and how many are scored at once.
+type fileFlags struct {
+	include    string
+	exclude    string
+	extensions string
+	jobs       int
+}
+
+func (f *fileFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.include, "include", "", "comma separated patterns of the files of directories to score, e.g. \"*.md,guide/*\"")
+	flags.StringVar(&f.exclude, "exclude", "", "comma separated patterns of the files and directories of directories to skip")
+	flags.StringVar(&f.extensions, "ext", "", "comma separated extensions of the files of directories to score (default those of the formats read)")
+	flags.IntVar(&f.jobs, "jobs", runtime.NumCPU(), "number of files to score at once")
+}
+
+// fileFilter chooses the files found in directories.
+type fileFilter struct {
+	include    []string
+	exclude    []string
+	extensions map[string]bool
+}
+
+// filter turns the flags into a filter, checking their patterns.
+func (f fileFlags) filter() (fileFilter, error) {
+	filter := fileFilter{
+		include: splitList(f.include),
+		exclude: splitList(f.exclude),
+	}
+	for _, pattern := range append(filter.include, filter.exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fileFilter{}, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+	}
+	if extensions := splitList(f.extensions); len(extensions) > 0 {
+		filter.extensions = make(map[string]bool)
+		for _, ext := range extensions {
+			filter.extensions["."+strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+		}
+	}
+
+	return filter, nil
+}
+
+// matches reports whether any pattern matches a file's path relative to
+// the directory it was found in, or its base name.
+func matches(patterns []string, rel string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(pattern)
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(rel)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// accepts reports whether a file found in a directory is to be scored:
+// one of a format the parser reads, or of the extensions given, that
+// matches an include pattern, if there are any, and no exclude pattern.
+func (f fileFilter) accepts(rel string) bool {
+	if f.extensions != nil {
+		if !f.extensions[strings.ToLower(filepath.Ext(rel))] {
+			return false
+		}
+	} else if flesch.DetectFormat(rel) == "" {
+		return false
+	}
+	if len(f.include) > 0 && !matches(f.include, rel) {
+		return false
+	}
+
+	return !matches(f.exclude, rel)
+}
+
+// expandArgs lists the files named by the arguments, in order and each
+// once. A glob pattern stands for the files and directories it matches,
+// and a directory for the files within it the filter accepts, searched
+// recursively. Hidden files and directories are skipped, unless named.
+// "-" stands for standard input.
+func expandArgs(args []string, filter fileFilter) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	for _, arg := range args {
+		if arg == stdinArg {
+			add(arg)
+			continue
+		}
+		paths := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			var err error
+			paths, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", arg, err)
+			}
+			if len(paths) == 0 {
+				return nil, fmt.Errorf("no files match %s", arg)
+			}
+			// as in a shell, wildcards do not match hidden names
+			var visible []string
+			for _, path := range paths {
+				if !strings.HasPrefix(filepath.Base(path), ".") || strings.HasPrefix(filepath.Base(arg), ".") {
+					visible = append(visible, path)
+				}
+			}
+			paths = visible
+		}
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(path)
+				continue
+			}
+			err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(path, file)
+				if err != nil {
+					return err
+				}
+				if rel == "." {
+					return nil
+				}
+				if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() && matches(filter.exclude, rel) {
+					if entry.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if entry.Type().IsRegular() && filter.accepts(rel) {
+					add(file)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("searching %s: %w", path, err)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,127 @@
+package flesch
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// SyllableCounter counts the syllables in a single word.
+type SyllableCounter interface {
+	CountSyllables(word string) int
+}
+
+// SyllableCounterFunc adapts an ordinary function to a SyllableCounter.
+type SyllableCounterFunc func(word string) int
+
+func (f SyllableCounterFunc) CountSyllables(word string) int {
+	return f(word)
+}
+
+// HeuristicCounter counts syllables with the vowel rules described
+// on Word.Syllables. It is what words use unless told otherwise.
+var HeuristicCounter SyllableCounter = SyllableCounterFunc(SyllablesFromString)
+
+// DictionaryCounter counts syllables by looking words up in a
+// pronouncing dictionary. Words the dictionary does not contain
+// are counted by its fallback.
+type DictionaryCounter struct {
+	syllables map[string]int
+	fallback  SyllableCounter
+}
+
+// NewDictionaryCounter reads a pronouncing dictionary in the format of
+// the CMU Pronouncing Dictionary: one word per line followed by its
+// ARPAbet phonemes, with vowel phonemes marked by a stress digit. Lines
+// starting with ";;;" are comments, and only the first pronunciation
+// of a word is used. A nil fallback means HeuristicCounter.
+func NewDictionaryCounter(r io.Reader, fallback SyllableCounter) (*DictionaryCounter, error) {
+	if fallback == nil {
+		fallback = HeuristicCounter
+	}
+	counter := &DictionaryCounter{
+		syllables: make(map[string]int),
+		fallback:  fallback,
+	}
+	scanner := bufio.NewScanner(r)
+	var lineNumber int
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";;;") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: no phonemes for %s", lineNumber, fields[0])
+		}
+		word := dictionaryKey(fields[0])
+		// alternate pronunciations look like WORD(2)
+		if strings.HasSuffix(word, ")") {
+			continue
+		}
+		if _, exists := counter.syllables[word]; exists {
+			continue
+		}
+		var syllables int
+		for _, phoneme := range fields[1:] {
+			if unicode.IsDigit(rune(phoneme[len(phoneme)-1])) {
+				syllables++
+			}
+		}
+		if syllables == 0 {
+			return nil, fmt.Errorf("line %d: no vowel phonemes for %s", lineNumber, fields[0])
+		}
+		counter.syllables[word] = syllables
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading dictionary: %w", err)
+	}
+
+	return counter, nil
+}
+
+// Contains reports whether the dictionary has a pronunciation for word.
+func (c *DictionaryCounter) Contains(word string) bool {
+	_, ok := c.syllables[dictionaryKey(word)]
+
+	return ok
+}
+
+func (c *DictionaryCounter) CountSyllables(word string) int {
+	if syllables, ok := c.syllables[dictionaryKey(word)]; ok {
+		return syllables
+	}
+
+	return c.fallback.CountSyllables(word)
+}
+
+func dictionaryKey(word string) string {
+	return straightApostrophes.Replace(strings.ToLower(word))
+}
+
+//go:embed data/cmudict.txt
+var cmuDictionary string
+
+var (
+	cmuCounter     *DictionaryCounter
+	loadCMUCounter sync.Once
+)
+
+// CMUCounter returns a DictionaryCounter for the pronouncing dictionary
+// embedded in the package, falling back to HeuristicCounter.
+func CMUCounter() *DictionaryCounter {
+	loadCMUCounter.Do(func() {
+		counter, err := NewDictionaryCounter(strings.NewReader(cmuDictionary), nil)
+		if err != nil {
+			panic(fmt.Sprintf("flesch: embedded dictionary: %s", err))
+		}
+		cmuCounter = counter
+	})
+
+	return cmuCounter
+}
@@ -0,0 +1,78 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+func TestCMUCounter(t *testing.T) {
+	testCases := []SyllableTestResult{
+		{"every", 3},
+		{"Business", 2},
+		{"created", 3},
+		{"rhythm", 2},
+		{"idea", 3},
+		{"loved", 1},
+		// not in the dictionary, so counted by the heuristic
+		{"carrot", 2},
+		{"consecrated", flesch.SyllablesFromString("consecrated")},
+	}
+	counter := flesch.CMUCounter()
+	for _, test := range testCases {
+		result := counter.CountSyllables(test.Word)
+		if test.Expected != result {
+			t.Errorf("%s: expected %d syllables, got %d", test.Word, test.Expected, result)
+		}
+	}
+}
+
+func TestNewDictionaryCounter(t *testing.T) {
+	dictionary := `;;; comment
+TOMATO  T AH0 M EY1 T OW2
+TOMATO(2)  T AH0 M AA1 T OW2
+`
+	fallback := flesch.SyllableCounterFunc(func(word string) int {
+		return 42
+	})
+	counter, err := flesch.NewDictionaryCounter(strings.NewReader(dictionary), fallback)
+	if err != nil {
+		t.Fatalf("reading dictionary: %s", err)
+	}
+	if !counter.Contains("Tomato") {
+		t.Errorf("expected dictionary to contain tomato")
+	}
+	if syllables := counter.CountSyllables("tomato"); syllables != 3 {
+		t.Errorf("tomato: expected 3 syllables, got %d", syllables)
+	}
+	if syllables := counter.CountSyllables("potato"); syllables != 42 {
+		t.Errorf("potato: expected fallback of 42 syllables, got %d", syllables)
+	}
+
+	_, err = flesch.NewDictionaryCounter(strings.NewReader("TOMATO\n"), nil)
+	if err == nil {
+		t.Errorf("expected an error for a word without phonemes")
+	}
+}
+
+func TestWithSyllableCounter(t *testing.T) {
+	text := "Every business created a rhythm."
+	heuristic, err := flesch.ParseString(text, "heuristic")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	dictionary, err := flesch.ParseString(text, "dictionary", flesch.WithSyllableCounter(flesch.CMUCounter()))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	// every: 2 → 3, business: 3 → 2, created: 2 → 3, a: 1, rhythm: 1 → 2
+	if heuristic.Syllables() != 9 {
+		t.Errorf("expected 9 syllables with the heuristic, got %d", heuristic.Syllables())
+	}
+	if dictionary.Syllables() != 11 {
+		t.Errorf("expected 11 syllables with the dictionary, got %d", dictionary.Syllables())
+	}
+	if recounted := heuristic.WithCounter(flesch.CMUCounter()); recounted.Score() != dictionary.Score() {
+		t.Errorf("expected recounted score %v, got %v", dictionary.Score(), recounted.Score())
+	}
+}
@@ -0,0 +1,140 @@
+package flesch
+
+import "math"
+
+// Counts holds the totals the readability formulas are computed from.
+// Unlike a Document, it does not keep any text, so it can be used to
+// score input of any size with ParseStream.
+type Counts struct {
+	Sentences int
+	Words     int
+	Syllables int
+	// Letters and digits in words
+	Characters int
+	// Words of a single syllable
+	Monosyllables int
+	// Words of three or more syllables
+	Polysyllables int
+	// Polysyllables that are not proper nouns, compounds or made so by
+	// an inflection, as defined for the Gunning Fog Index
+	ComplexWords int
+}
+
+// Add includes a sentence in the totals.
+func (c *Counts) Add(s Sentence) {
+	c.Sentences++
+	for i, w := range s.Words {
+		syllables := w.Syllables()
+		c.Words++
+		c.Syllables += syllables
+		c.Characters += w.Characters()
+		switch {
+		case syllables == 1:
+			c.Monosyllables++
+		case syllables >= 3:
+			c.Polysyllables++
+			if isComplexWord(w, syllables, i == 0) {
+				c.ComplexWords++
+			}
+		}
+	}
+}
+
+// Merge includes the totals of another set of counts, such as
+// those of another document.
+func (c *Counts) Merge(other Counts) {
+	c.Sentences += other.Sentences
+	c.Words += other.Words
+	c.Syllables += other.Syllables
+	c.Characters += other.Characters
+	c.Monosyllables += other.Monosyllables
+	c.Polysyllables += other.Polysyllables
+	c.ComplexWords += other.ComplexWords
+}
+
+func (c Counts) Score() float32 {
+	score := 206.835 - (84.6 * c.avgSylPerWord()) - (1.015 * c.avgWordPerSen())
+
+	return score
+}
+
+func (c Counts) Kincaid() float32 {
+	score := .39*c.avgWordPerSen() + 11.8*c.avgSylPerWord() - 15.59
+
+	return score
+}
+
+func (c Counts) ReadableScore() string {
+	return readableScore(c.Score())
+}
+
+func (c Counts) avgWordPerSen() float32 {
+	words := float32(c.Words)
+	sentences := float32(c.Sentences)
+
+	return words / sentences
+}
+
+func (c Counts) avgSylPerWord() float32 {
+	syllables := float32(c.Syllables)
+	words := float32(c.Words)
+
+	return syllables / words
+}
+
+func (c Counts) GunningFog() float32 {
+	complexRatio := float32(c.ComplexWords) / float32(c.Words)
+
+	return .4 * (c.avgWordPerSen() + 100*complexRatio)
+}
+
+func (c Counts) SMOG() float32 {
+	polysyllables := float64(c.Polysyllables) * 30 / float64(c.Sentences)
+
+	return float32(1.043*math.Sqrt(polysyllables) + 3.1291)
+}
+
+func (c Counts) ColemanLiau() float32 {
+	lettersPer100 := float32(c.Characters) / float32(c.Words) * 100
+	sentencesPer100 := float32(c.Sentences) / float32(c.Words) * 100
+
+	return .0588*lettersPer100 - .296*sentencesPer100 - 15.8
+}
+
+func (c Counts) AutomatedReadability() float32 {
+	charactersPerWord := float32(c.Characters) / float32(c.Words)
+
+	return 4.71*charactersPerWord + .5*c.avgWordPerSen() - 21.43
+}
+
+// FernandezHuerta is the Spanish adaptation of the Reading Ease Score,
+// in the corrected form that uses words per sentence.
+func (c Counts) FernandezHuerta() float32 {
+	return 206.84 - 60*c.avgSylPerWord() - 1.02*c.avgWordPerSen()
+}
+
+// SzigrisztPazos is the Flesch–Szigriszt perspicuity index for Spanish.
+func (c Counts) SzigrisztPazos() float32 {
+	return 206.835 - 62.3*c.avgSylPerWord() - c.avgWordPerSen()
+}
+
+// Amstad is the German adaptation of the Reading Ease Score.
+func (c Counts) Amstad() float32 {
+	return 180 - c.avgWordPerSen() - 58.5*c.avgSylPerWord()
+}
+
+// KandelMoles is the French adaptation of the Reading Ease Score.
+func (c Counts) KandelMoles() float32 {
+	return 207 - 1.015*c.avgWordPerSen() - 73.6*c.avgSylPerWord()
+}
+
+// Douma is the Dutch adaptation of the Reading Ease Score.
+func (c Counts) Douma() float32 {
+	return 206.84 - .93*c.avgWordPerSen() - 77*c.avgSylPerWord()
+}
+
+// FleschVacca is the Italian adaptation of the Reading Ease Score by
+// Franchina and Vacca.
+func (c Counts) FleschVacca() float32 {
+	return 206 - 65*c.avgSylPerWord() - c.avgWordPerSen()
+}
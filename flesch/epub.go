@@ -0,0 +1,224 @@
+package flesch
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"golang.org/x/net/html"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+const epubMimeType = "application/epub+zip"
+
+// Book is an EPUB book, parsed into a Document per chapter.
+type Book struct {
+	Title    string
+	Chapters []Chapter
+	name     string
+	language *Language
+}
+
+// Chapter is a document of a book's spine, the book's reading order. The
+// positions of its sentences and words point into the chapter's file.
+type Chapter struct {
+	Document
+	// Title is the chapter's first heading, or failing that the title of
+	// its file
+	Title string
+	// Href is the path of the chapter's file within the book
+	Href string
+}
+
+// Document joins the chapters into a single document for the whole book.
+func (b Book) Document() Document {
+	document := Document{name: b.name, language: b.language}
+	for _, chapter := range b.Chapters {
+		document.Sentences = append(document.Sentences, chapter.Sentences...)
+	}
+
+	return document
+}
+
+// ParseEPUB parses each chapter of an EPUB book, in reading order.
+func ParseEPUB(filename string, options ...Option) (Book, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Book{name: filename}, fmt.Errorf("reading %s: %w", filename, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return Book{name: filename}, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	book, err := ReadEPUB(file, info.Size(), filename, options...)
+	if err != nil {
+		return book, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	return book, nil
+}
+
+// ReadEPUB parses each chapter of the EPUB book in r, in reading order.
+// Chapters are the XHTML documents of the book's spine, except those
+// outside the linear reading order and those with no prose, such as a
+// cover image. They are named after the book and their path within it.
+func ReadEPUB(r io.ReaderAt, size int64, name string, options ...Option) (Book, error) {
+	book := Book{name: name, language: newConfig(options).lang()}
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return book, err
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+	readFile := func(name string) ([]byte, error) {
+		file, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("no %s in book", name)
+		}
+		return readZipFile(file)
+	}
+
+	var container struct {
+		Rootfiles []struct {
+			FullPath string `xml:"full-path,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}
+	if err := readXML(readFile, "META-INF/container.xml", &container); err != nil {
+		return book, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return book, fmt.Errorf("no package document in book")
+	}
+	packagePath := container.Rootfiles[0].FullPath
+	var pkg struct {
+		Title    []string `xml:"metadata>title"`
+		Manifest []struct {
+			ID        string `xml:"id,attr"`
+			Href      string `xml:"href,attr"`
+			MediaType string `xml:"media-type,attr"`
+		} `xml:"manifest>item"`
+		Spine []struct {
+			IDRef  string `xml:"idref,attr"`
+			Linear string `xml:"linear,attr"`
+		} `xml:"spine>itemref"`
+	}
+	if err := readXML(readFile, packagePath, &pkg); err != nil {
+		return book, err
+	}
+	if len(pkg.Title) > 0 {
+		book.Title = strings.TrimSpace(pkg.Title[0])
+	}
+
+	hrefs := make(map[string]string)
+	for _, item := range pkg.Manifest {
+		if item.MediaType == "application/xhtml+xml" || item.MediaType == "text/html" {
+			hrefs[item.ID] = item.Href
+		}
+	}
+	chapterOptions := append(append([]Option{}, options...), WithFormat(FormatHTML))
+	for _, itemref := range pkg.Spine {
+		href, ok := hrefs[itemref.IDRef]
+		if !ok || itemref.Linear == "no" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+		href = path.Join(path.Dir(packagePath), href)
+		source, err := readFile(href)
+		if err != nil {
+			return book, err
+		}
+		document, err := Parse(bytes.NewReader(source), path.Join(name, href), chapterOptions...)
+		if err != nil {
+			return book, fmt.Errorf("%s: %w", href, err)
+		}
+		if len(document.Sentences) == 0 {
+			continue
+		}
+		title := chapterTitle(source)
+		if title == "" {
+			title = path.Base(href)
+		}
+		book.Chapters = append(book.Chapters, Chapter{Document: document, Title: title, Href: href})
+	}
+
+	return book, nil
+}
+
+func readXML(readFile func(string) ([]byte, error), name string, v interface{}) error {
+	data, err := readFile(name)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+// chapterTitle finds the text of the first heading of a chapter, or of
+// its title if it has no headings.
+func chapterTitle(source []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(source))
+	var title, heading strings.Builder
+	var inTitle, inHeading bool
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.Join(strings.Fields(title.String()), " ")
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "title":
+				inTitle = true
+			case isHeadingTag(tag):
+				inHeading = true
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "title":
+				inTitle = false
+			case inHeading && isHeadingTag(tag):
+				if text := strings.Join(strings.Fields(heading.String()), " "); text != "" {
+					return text
+				}
+				inHeading = false
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+			}
+			if inHeading {
+				heading.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// streamEPUB calls fn with each sentence of each chapter of a book.
+func streamEPUB(source []byte, fn func(Sentence) error, options []Option) error {
+	book, err := ReadEPUB(bytes.NewReader(source), int64(len(source)), "", options...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", FormatEPUB, err)
+	}
+	for _, chapter := range book.Chapters {
+		for _, sentence := range chapter.Sentences {
+			if err := fn(sentence); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package flesch_test
+
+import (
+	"bytes"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"testing"
+)
+
+func epubFile(t *testing.T) []byte {
+	chapter := func(title, body string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"><head><title>` + title + `</title></head>
+<body>` + body + `</body></html>`
+	}
+	return zipArchive(t,
+		[2]string{"mimetype", "application/epub+zip"},
+		[2]string{"META-INF/container.xml", `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`},
+		[2]string{"OEBPS/content.opf", `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>A Small Book</dc:title></metadata>
+<manifest>
+<item id="cover" href="cover.xhtml" media-type="application/xhtml+xml"/>
+<item id="one" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+<item id="two" href="text/two.xhtml" media-type="application/xhtml+xml"/>
+<item id="notes" href="text/notes.xhtml" media-type="application/xhtml+xml"/>
+<item id="css" href="style.css" media-type="text/css"/>
+</manifest>
+<spine><itemref idref="cover"/><itemref idref="two"/><itemref idref="notes" linear="no"/><itemref idref="one"/></spine>
+</package>`},
+		[2]string{"OEBPS/cover.xhtml", chapter("Cover", `<img src="cover.png" alt="Cover"/>`)},
+		[2]string{"OEBPS/text/chapter 1.xhtml", chapter("Book", `<h1>The Start</h1><p>It was a dark night. The end came soon.</p>`)},
+		[2]string{"OEBPS/text/two.xhtml", chapter("Second", `<p>Consideration of the extraordinary circumstances necessitated deliberation.</p>`)},
+		[2]string{"OEBPS/text/notes.xhtml", chapter("Notes", `<p>Not in the reading order.</p>`)},
+		[2]string{"OEBPS/style.css", `p { margin: 0; }`},
+	)
+}
+
+func TestReadEPUB(t *testing.T) {
+	data := epubFile(t)
+	book, err := flesch.ReadEPUB(bytes.NewReader(data), int64(len(data)), "small.epub")
+	if err != nil {
+		t.Fatalf("reading: %s", err)
+	}
+	if book.Title != "A Small Book" {
+		t.Errorf("expected the title A Small Book, got %q", book.Title)
+	}
+	// the spine decides the order, and the cover and notes are left out
+	if len(book.Chapters) != 2 {
+		t.Fatalf("expected 2 chapters, got %d", len(book.Chapters))
+	}
+	expected := []struct{ title, name string }{
+		{"Second", "small.epub/OEBPS/text/two.xhtml"},
+		{"The Start", "small.epub/OEBPS/text/chapter 1.xhtml"},
+	}
+	for i, chapter := range book.Chapters {
+		if chapter.Title != expected[i].title || chapter.Name() != expected[i].name {
+			t.Errorf("expected chapter %d to be %q in %s, got %q in %s", i+1, expected[i].title, expected[i].name, chapter.Title, chapter.Name())
+		}
+	}
+	if book.Chapters[0].Score() >= book.Chapters[1].Score() {
+		t.Errorf("expected the second chapter to read more easily than the first")
+	}
+
+	whole := book.Document()
+	if whole.Name() != "small.epub" || whole.WordCount() != 18 {
+		t.Errorf("expected 18 words in small.epub, got %d in %s", whole.WordCount(), whole.Name())
+	}
+	// parsing the book as a single document reads the same words
+	document, err := flesch.ParseString(string(data), "small.epub")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if document.Counts() != whole.Counts() {
+		t.Errorf("expected counts %+v, got %+v", whole.Counts(), document.Counts())
+	}
+}
@@ -0,0 +1,65 @@
+package flesch
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Format is a kind of document the parser reads. Only the prose of a
+// marked-up format is scored, and the positions of its sentences and
+// words point into the original source. Their Start and End offsets
+// remain rune offsets within the extracted prose. Word processor files
+// have no plain text source; the line of a position in one of them is
+// the number of its paragraph.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatDOCX     Format = "docx"
+	FormatODT      Format = "odt"
+	FormatEPUB     Format = "epub"
+)
+
+// extractors extract the prose of every format but plain text and EPUB,
+// whose chapters are parsed one by one.
+var extractors = map[Format]func(source []byte, c config) (*prose, error){
+	FormatMarkdown: extractMarkdown,
+	FormatHTML:     extractHTML,
+	FormatDOCX:     extractDOCX,
+	FormatODT:      extractODT,
+}
+
+// Formats lists the formats the parser reads.
+func Formats() []Format {
+	formats := []Format{FormatText, FormatEPUB}
+	for format := range extractors {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats[1:], func(i, j int) bool { return formats[i+1] < formats[j+1] })
+
+	return formats
+}
+
+// DetectFormat guesses the format of a file from its name, returning ""
+// if the name says nothing about it.
+func DetectFormat(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return FormatMarkdown
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML
+	case ".docx":
+		return FormatDOCX
+	case ".odt":
+		return FormatODT
+	case ".epub":
+		return FormatEPUB
+	case ".txt", ".text":
+		return FormatText
+	}
+
+	return ""
+}
@@ -0,0 +1,257 @@
+package flesch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Formula is a readability formula computed from a parsed Document.
+type Formula struct {
+	// Key is the short name used to select the formula, e.g. "fog"
+	Key     string
+	Name    string
+	Compute func(Document) float32
+	// Band names the reading level of a score, like "7th grade", or is
+	// nil for formulas without one
+	Band func(score float32) string
+	// FromCounts computes the score from totals alone, such as those of
+	// several documents merged, or is nil for formulas that need the text
+	FromCounts func(Counts, *Language) float32
+	// Languages are the codes of the languages the formula was fitted
+	// to, e.g. "es", or nil for formulas that apply to any
+	Languages []string
+}
+
+// AppliesTo reports whether a formula was fitted to a language, English
+// if it is nil.
+func (f Formula) AppliesTo(language *Language) bool {
+	if f.Languages == nil {
+		return true
+	}
+	if language == nil {
+		language = English
+	}
+	for _, code := range f.Languages {
+		if code == language.Code {
+			return true
+		}
+	}
+
+	return false
+}
+
+var formulas = []Formula{
+	{"ease", "Flesch Reading Ease Score", Document.Score, easeBand, languageEase, nil},
+	{"kincaid", "Flesch–Kincaid Grade Level", Document.Kincaid, gradeBand, fromCounts(Counts.Kincaid), []string{"en"}},
+	{"fog", "Gunning Fog Index", Document.GunningFog, gradeBand, fromCounts(Counts.GunningFog), []string{"en"}},
+	{"smog", "SMOG Grade", Document.SMOG, gradeBand, fromCounts(Counts.SMOG), []string{"en"}},
+	{"coleman-liau", "Coleman–Liau Index", Document.ColemanLiau, gradeBand, fromCounts(Counts.ColemanLiau), []string{"en"}},
+	{"ari", "Automated Readability Index", Document.AutomatedReadability, gradeBand, fromCounts(Counts.AutomatedReadability), []string{"en"}},
+	{"linsear", "Linsear Write Formula", Document.LinsearWrite, gradeBand, nil, []string{"en"}},
+	{"forcast", "FORCAST Grade Level", Document.Forcast, gradeBand, nil, []string{"en"}},
+	{"dale-chall", "New Dale–Chall Score", Document.DaleChall, daleChallBand, nil, []string{"en"}},
+	{"spache", "Spache Grade Level", Document.Spache, gradeBand, nil, []string{"en"}},
+	{"fernandez-huerta", "Fernández-Huerta Score", countsFormula(Counts.FernandezHuerta), easeBand, fromCounts(Counts.FernandezHuerta), []string{"es"}},
+	{"szigriszt-pazos", "Szigriszt-Pazos Perspicuity", countsFormula(Counts.SzigrisztPazos), easeBand, fromCounts(Counts.SzigrisztPazos), []string{"es"}},
+	{"amstad", "Amstad Score", countsFormula(Counts.Amstad), easeBand, fromCounts(Counts.Amstad), []string{"de"}},
+	{"kandel-moles", "Kandel–Moles Score", countsFormula(Counts.KandelMoles), easeBand, fromCounts(Counts.KandelMoles), []string{"fr"}},
+	{"douma", "Douma Score", countsFormula(Counts.Douma), easeBand, fromCounts(Counts.Douma), []string{"nl"}},
+	{"flesch-vacca", "Flesch–Vacca Score", countsFormula(Counts.FleschVacca), easeBand, fromCounts(Counts.FleschVacca), []string{"it"}},
+}
+
+// easeBand names the reading level of a reading ease score, as
+// ReadableScore does, without its padding.
+func easeBand(score float32) string {
+	return strings.TrimSpace(readableScore(score))
+}
+
+// gradeBand names the reading level of a US grade level, in the bands
+// of the reading ease score.
+func gradeBand(grade float32) string {
+	switch {
+	case grade < 5:
+		return "4th grade or below"
+	case grade < 6:
+		return "5th grade"
+	case grade < 7:
+		return "6th grade"
+	case grade < 8:
+		return "7th grade"
+	case grade < 10:
+		return "8th & 9th grade"
+	case grade < 13:
+		return "10th to 12th grade"
+	case grade < 17:
+		return "College"
+	}
+
+	return "College graduate"
+}
+
+// daleChallBand names the reading level of a New Dale–Chall score,
+// which is not a grade level itself.
+func daleChallBand(score float32) string {
+	switch {
+	case score < 5:
+		return "4th grade or below"
+	case score < 6:
+		return "5th & 6th grade"
+	case score < 7:
+		return "7th & 8th grade"
+	case score < 8:
+		return "9th & 10th grade"
+	case score < 9:
+		return "11th & 12th grade"
+	case score < 10:
+		return "College"
+	}
+
+	return "College graduate"
+}
+
+func fromCounts(formula func(Counts) float32) func(Counts, *Language) float32 {
+	return func(c Counts, _ *Language) float32 {
+		return formula(c)
+	}
+}
+
+// languageEase is the reading ease formula of a language, English if
+// it is nil.
+func languageEase(c Counts, language *Language) float32 {
+	if language == nil {
+		language = English
+	}
+
+	return language.Ease(c)
+}
+
+func countsFormula(formula func(Counts) float32) func(Document) float32 {
+	return func(d Document) float32 {
+		return formula(d.Counts())
+	}
+}
+
+// Formulas lists every registered formula in the order they are usually
+// reported.
+func Formulas() []Formula {
+	list := make([]Formula, len(formulas))
+	copy(list, formulas)
+
+	return list
+}
+
+// LookupFormula finds a registered formula by its key, ignoring case.
+func LookupFormula(key string) (Formula, bool) {
+	for _, formula := range formulas {
+		if strings.EqualFold(formula.Key, key) {
+			return formula, true
+		}
+	}
+
+	return Formula{}, false
+}
+
+// RegisterFormula adds a formula to the registry, replacing any formula
+// with the same key. It is meant to be called from init functions.
+func RegisterFormula(formula Formula) {
+	for i := range formulas {
+		if strings.EqualFold(formulas[i].Key, formula.Key) {
+			formulas[i] = formula
+			return
+		}
+	}
+	formulas = append(formulas, formula)
+}
+
+func (d Document) GunningFog() float32 {
+	return d.Counts().GunningFog()
+}
+
+// SMOG estimates the grade level from the number of polysyllables,
+// scaled to a sample of 30 sentences.
+func (d Document) SMOG() float32 {
+	return d.Counts().SMOG()
+}
+
+func (d Document) ColemanLiau() float32 {
+	return d.Counts().ColemanLiau()
+}
+
+func (d Document) AutomatedReadability() float32 {
+	return d.Counts().AutomatedReadability()
+}
+
+// LinsearWrite scores a sample of the first 100 words: easy words of
+// one or two syllables count as 1 and hard words as 3. The total is
+// divided by the number of sentences the sample covers.
+func (d Document) LinsearWrite() float32 {
+	const sampleSize = 100
+	var points, words, sentences int
+	for _, s := range d.Sentences {
+		if words >= sampleSize {
+			break
+		}
+		sentences++
+		for _, w := range s.Words {
+			if words >= sampleSize {
+				break
+			}
+			words++
+			if w.Syllables() >= 3 {
+				points += 3
+			} else {
+				points++
+			}
+		}
+	}
+	score := float32(points) / float32(sentences)
+	if score > 20 {
+		return score / 2
+	}
+
+	return (score - 2) / 2
+}
+
+// Forcast counts single syllable words in a sample of the first 150
+// words. Shorter documents are scaled up to the sample size.
+func (d Document) Forcast() float32 {
+	const sampleSize = 150
+	var monosyllables, words int
+	for _, w := range d.Words() {
+		if words >= sampleSize {
+			break
+		}
+		words++
+		if w.Syllables() == 1 {
+			monosyllables++
+		}
+	}
+	scaled := float32(monosyllables) * sampleSize / float32(words)
+
+	return 20 - scaled/10
+}
+
+// isComplexWord reports whether a word of three or more syllables counts
+// toward the Gunning Fog Index. Proper nouns, hyphenated compounds and
+// words that only reach three syllables through an -es, -ed or -ing
+// ending do not.
+func isComplexWord(w Word, syllables int, sentenceStart bool) bool {
+	runes := w.Runes()
+	if !sentenceStart && unicode.IsUpper(runes[0]) {
+		return false
+	}
+	word := strings.ToLower(w.String())
+	if strings.ContainsRune(word, '-') {
+		return false
+	}
+	for _, suffix := range []string{"es", "ed", "ing"} {
+		if syllables == 3 && strings.HasSuffix(word, suffix) {
+			stem := strings.TrimSuffix(word, suffix)
+			if w.syllableCounter().CountSyllables(stem) < 3 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
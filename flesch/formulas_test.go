@@ -0,0 +1,156 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"math"
+	"testing"
+)
+
+func TestCounts(t *testing.T) {
+	document, err := flesch.ParseString("Education is important. Children learn quickly.", "counts")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	expected := flesch.Counts{
+		Sentences:     2,
+		Words:         6,
+		Syllables:     12,
+		Characters:    40,
+		Monosyllables: 3,
+		Polysyllables: 2,
+		ComplexWords:  2,
+	}
+	if counts := document.Counts(); counts != expected {
+		t.Errorf("expected counts %+v, got %+v", expected, counts)
+	}
+}
+
+func TestFormulas(t *testing.T) {
+	document, err := flesch.ParseString("Education is important. Children learn quickly.", "formulas")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	expected := map[string]float64{
+		"fog":          14.53,
+		"smog":         8.84,
+		"coleman-liau": 13.53,
+		"ari":          11.47,
+		"linsear":      1.5,
+		"forcast":      12.5,
+	}
+	for key, score := range expected {
+		formula, ok := flesch.LookupFormula(key)
+		if !ok {
+			t.Errorf("formula %s is not registered", key)
+			continue
+		}
+		result := float64(formula.Compute(document))
+		if math.Abs(result-score) > .01 {
+			t.Errorf("%s: expected %.2f, got %.2f", formula.Name, score, result)
+		}
+	}
+}
+
+func TestComplexWords(t *testing.T) {
+	// proper nouns, compounds and inflected words are not complex
+	document, err := flesch.ParseString("We met Elizabeth at a well-coordinated reception, decided.", "complex")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	counts := document.Counts()
+	if counts.ComplexWords != 1 {
+		t.Errorf("expected 1 complex word, got %d of %d polysyllables", counts.ComplexWords, counts.Polysyllables)
+	}
+}
+
+func TestLookupFormula(t *testing.T) {
+	if _, ok := flesch.LookupFormula("KINCAID"); !ok {
+		t.Errorf("expected lookup to ignore case")
+	}
+	if _, ok := flesch.LookupFormula("tarot"); ok {
+		t.Errorf("expected no formula for an unknown key")
+	}
+	if len(flesch.Formulas()) < 8 {
+		t.Errorf("expected at least 8 formulas, got %d", len(flesch.Formulas()))
+	}
+}
+
+func TestFormulaBands(t *testing.T) {
+	expected := []struct {
+		key   string
+		score float32
+		band  string
+	}{
+		{"ease", 75, "7th grade"},
+		{"kincaid", 7.5, "7th grade"},
+		{"fog", 11, "10th to 12th grade"},
+		{"dale-chall", 7.5, "9th & 10th grade"},
+		{"smog", 18, "College graduate"},
+	}
+	for _, e := range expected {
+		formula, _ := flesch.LookupFormula(e.key)
+		if formula.Band == nil {
+			t.Errorf("%s: expected a band", e.key)
+			continue
+		}
+		if band := formula.Band(e.score); band != e.band {
+			t.Errorf("%s of %.1f: expected band %q, got %q", e.key, e.score, e.band, band)
+		}
+	}
+}
+
+func TestFromCounts(t *testing.T) {
+	first, err := flesch.ParseString("Education is important. Children learn quickly.", "first")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	second, err := flesch.ParseString("The committee deliberated for several hours.", "second")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	both, err := flesch.ParseString("Education is important. Children learn quickly. The committee deliberated for several hours.", "both")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	counts := first.Counts()
+	counts.Merge(second.Counts())
+	for _, formula := range flesch.Formulas() {
+		if formula.FromCounts == nil {
+			continue
+		}
+		expected := formula.Compute(both)
+		if score := formula.FromCounts(counts, both.Language()); math.Abs(float64(score-expected)) > .01 {
+			t.Errorf("%s: expected %.2f from merged counts, got %.2f", formula.Name, expected, score)
+		}
+	}
+	if formula, _ := flesch.LookupFormula("dale-chall"); formula.FromCounts != nil {
+		t.Errorf("expected no score from counts for a word list formula")
+	}
+}
+
+func TestFormulaAppliesTo(t *testing.T) {
+	tests := []struct {
+		key      string
+		language *flesch.Language
+		applies  bool
+	}{
+		{"ease", flesch.French, true},
+		{"kincaid", flesch.English, true},
+		{"kincaid", nil, true},
+		{"kincaid", flesch.Spanish, false},
+		{"fernandez-huerta", flesch.Spanish, true},
+		{"fernandez-huerta", flesch.English, false},
+		{"douma", flesch.Dutch, true},
+		{"douma", nil, false},
+	}
+	for _, test := range tests {
+		formula, _ := flesch.LookupFormula(test.key)
+		name := "no language"
+		if test.language != nil {
+			name = test.language.Name
+		}
+		if applies := formula.AppliesTo(test.language); applies != test.applies {
+			t.Errorf("%s of %s: expected applies %t, got %t", test.key, name, test.applies, applies)
+		}
+	}
+}
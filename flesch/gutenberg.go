@@ -0,0 +1,198 @@
+package flesch
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StripReason says why a part of a document was left out of scoring.
+type StripReason string
+
+const (
+	// StripGutenbergHeader is the Project Gutenberg header, its license
+	// and the credits of whoever produced the ebook
+	StripGutenbergHeader StripReason = "gutenberg header"
+	// StripGutenbergFooter is the Project Gutenberg license after the end
+	// of the ebook
+	StripGutenbergFooter StripReason = "gutenberg footer"
+	StripHeading         StripReason = "heading"
+	StripContents        StripReason = "table of contents"
+)
+
+// StrippedRange is a run of lines of plain text left out of scoring.
+// Start is the start of its first line and End the end of its last,
+// excluding the line break.
+type StrippedRange struct {
+	Start, End Position
+	Reason     StripReason
+}
+
+var (
+	gutenbergStart = regexp.MustCompile(`(?i)^\s*(\*{3}\s*START OF (THE |THIS )?PROJECT GUTENBERG E-?BOOK|\*END\*\s*THE SMALL PRINT)`)
+	gutenbergEnd   = regexp.MustCompile(`(?i)^\s*(\*{3}\s*END OF (THE |THIS )?PROJECT GUTENBERG E-?BOOK|END OF (THE )?PROJECT GUTENBERG'?S?\b)`)
+	// gutenbergCredits start the paragraph after the header that names
+	// whoever produced the ebook
+	gutenbergCredits = regexp.MustCompile(`(?i)^\s*(produced by|e-?text prepared by|transcribed (from|by)|this e-?text was)\b`)
+	chapterHeading   = regexp.MustCompile(`(?i)^\s*(chapter|book|part|volume|section|stave|canto|letter)\s+([0-9]+|[ivxlcdm]+)\b`)
+	// chapterLine is a chapter heading that is nothing but its number
+	chapterLine = regexp.MustCompile(`(?i)^\s*(chapter|book|part|volume|section|stave|canto|letter)\s+([0-9]+|[ivxlcdm]+)\s*[.:]?\s*$`)
+	// pageNumber ends an entry of a table of contents, after dot leaders
+	// or a wide gap
+	pageNumber = regexp.MustCompile(`(\.{3,}|\S\s{3,})\s*([0-9]+|[ivxlc]+)\s*$`)
+)
+
+// maxHeadingLength is the longest line taken for a heading, in runes,
+// and maxHeadingLines the most lines a chapter heading is wrapped over.
+const (
+	maxHeadingLength = 80
+	maxHeadingLines  = 3
+)
+
+// stripText leaves the Project Gutenberg header and footer, and with
+// headings, the headings and tables of contents, out of plain text.
+// Only whole lines are left out; the rest of the text is copied so
+// that positions still point into the source.
+func stripText(source string, c config) (*prose, []StrippedRange) {
+	p := newProse(source)
+	lines := p.lines()
+	reasons := make([]StripReason, len(lines))
+	text := func(i int) string { return p.source[lines[i][0]:lines[i][1]] }
+	blank := func(i int) bool { return strings.TrimSpace(text(i)) == "" }
+
+	if c.gutenberg {
+		markGutenberg(reasons, text, blank)
+	}
+	if c.headings {
+		for i := 0; i < len(lines); i++ {
+			if reasons[i] != "" || blank(i) {
+				continue
+			}
+			line := text(i)
+			if isContentsEntry(line) && (pageNumber.MatchString(line) ||
+				i > 0 && isContentsEntry(text(i-1)) ||
+				i < len(lines)-1 && isContentsEntry(text(i+1))) {
+				reasons[i] = StripContents
+				continue
+			}
+			// a heading is a paragraph of its own: a line of capitals, or
+			// a chapter heading of a few lines
+			if i > 0 && !blank(i-1) && reasons[i-1] == "" {
+				continue
+			}
+			last := i
+			for last+1 < len(lines) && !blank(last+1) && reasons[last+1] == "" {
+				last++
+			}
+			if last == i && isCapitalized(line) || last-i < maxHeadingLines && chapterHeading.MatchString(line) {
+				for ; i <= last; i++ {
+					reasons[i] = StripHeading
+				}
+				i--
+			}
+		}
+	}
+	// blank lines between lines stripped for the same reason are part of
+	// the same range
+	for i := 0; i < len(lines); i++ {
+		if reasons[i] == "" {
+			continue
+		}
+		next := i + 1
+		for next < len(lines) && blank(next) && reasons[next] == "" {
+			next++
+		}
+		if next < len(lines) && next > i+1 && reasons[next] == reasons[i] {
+			for j := i + 1; j < next; j++ {
+				reasons[j] = reasons[i]
+			}
+		}
+	}
+
+	var stripped []StrippedRange
+	for i := 0; i < len(lines); i++ {
+		if reasons[i] == "" {
+			end := len(source)
+			if i < len(lines)-1 {
+				end = lines[i+1][0]
+			}
+			p.copy(lines[i][0], end)
+			continue
+		}
+		last := i
+		for last+1 < len(lines) && reasons[last+1] == reasons[i] {
+			last++
+		}
+		stripped = append(stripped, StrippedRange{
+			Start:  p.position(lines[i][0]),
+			End:    p.position(lines[last][1]),
+			Reason: reasons[i],
+		})
+		i = last
+	}
+
+	return p, stripped
+}
+
+// markGutenberg marks the lines up to the start marker of a Project
+// Gutenberg ebook, along with the credits that follow it, and those from
+// its end marker on. Without a start marker, the header is left alone,
+// and so is the footer without an end marker.
+func markGutenberg(reasons []StripReason, text func(int) string, blank func(int) bool) {
+	start := -1
+	for i := range reasons {
+		if gutenbergStart.MatchString(text(i)) {
+			start = i
+			break
+		}
+	}
+	if start >= 0 {
+		end := start + 1
+		for end < len(reasons) && blank(end) {
+			end++
+		}
+		if end < len(reasons) && gutenbergCredits.MatchString(text(end)) {
+			for end < len(reasons) && !blank(end) {
+				end++
+			}
+			start = end - 1
+		}
+		for i := 0; i <= start; i++ {
+			reasons[i] = StripGutenbergHeader
+		}
+	}
+	for i := start + 1; i < len(reasons); i++ {
+		if gutenbergEnd.MatchString(text(i)) {
+			for ; i < len(reasons); i++ {
+				reasons[i] = StripGutenbergFooter
+			}
+		}
+	}
+}
+
+// isContentsEntry reports whether a line looks like a chapter heading
+// or an entry of a table of contents.
+func isContentsEntry(line string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(line)) <= maxHeadingLength &&
+		(chapterHeading.MatchString(line) || pageNumber.MatchString(line))
+}
+
+// isCapitalized reports whether a short line has letters, all of them
+// capitals, like "ETYMOLOGY." or "PART ONE".
+func isCapitalized(line string) bool {
+	if utf8.RuneCountInString(strings.TrimSpace(line)) > maxHeadingLength {
+		return false
+	}
+	var letters int
+	for _, r := range line {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	return letters > 1
+}
@@ -0,0 +1,137 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"path"
+	"strings"
+	"testing"
+)
+
+const gutenbergSample = `The Project Gutenberg EBook of A Short Story, by Nobody
+
+This eBook is for the use of anyone anywhere at no cost.
+
+*** START OF THIS PROJECT GUTENBERG EBOOK A SHORT STORY ***
+
+
+
+
+Produced by Some Volunteers and the Online Distributed
+Proofreading Team.
+
+
+
+
+A SHORT STORY
+
+CONTENTS
+
+    CHAPTER I.     The Start ........ 1
+    CHAPTER II.    The End .......... 9
+
+
+CHAPTER I.
+
+The cat sat on the mat.
+It was happy.
+
+CHAPTER II.
+
+The dog did not sit. THE END was near.
+
+End of the Project Gutenberg EBook of A Short Story
+
+*** END OF THIS PROJECT GUTENBERG EBOOK A SHORT STORY ***
+
+Section 1. General Terms of Use.
+`
+
+func TestGutenbergStripping(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Options  []flesch.Option
+		Expected []string
+		Reasons  []flesch.StripReason
+	}{
+		{
+			"gutenberg",
+			[]flesch.Option{flesch.WithGutenbergStripping()},
+			[]string{"A SHORT STORY", "CONTENTS", "CHAPTER I.", "The Start ........ 1 CHAPTER II.", "The End .......... 9", "CHAPTER I.", "The cat sat on the mat.", "It was happy.", "CHAPTER II.", "The dog did not sit.", "THE END was near."},
+			[]flesch.StripReason{flesch.StripGutenbergHeader, flesch.StripGutenbergFooter},
+		},
+		{
+			"headings",
+			[]flesch.Option{flesch.WithGutenbergStripping(), flesch.WithHeadingStripping()},
+			[]string{"The cat sat on the mat.", "It was happy.", "The dog did not sit.", "THE END was near."},
+			[]flesch.StripReason{flesch.StripGutenbergHeader, flesch.StripHeading, flesch.StripContents, flesch.StripHeading, flesch.StripHeading, flesch.StripGutenbergFooter},
+		},
+	}
+	for _, test := range testCases {
+		document, err := flesch.ParseString(gutenbergSample, "story.txt", test.Options...)
+		if err != nil {
+			t.Fatalf("%s: parsing: %s", test.Name, err)
+		}
+		var sentences, reasons []string
+		for _, sentence := range document.Sentences {
+			sentences = append(sentences, strings.Join(strings.Fields(sentence.String()), " "))
+		}
+		for _, stripped := range document.Stripped {
+			reasons = append(reasons, string(stripped.Reason))
+		}
+		if strings.Join(sentences, "|") != strings.Join(test.Expected, "|") {
+			t.Errorf("%s: expected sentences %q, got %q", test.Name, test.Expected, sentences)
+		}
+		var expectedReasons []string
+		for _, reason := range test.Reasons {
+			expectedReasons = append(expectedReasons, string(reason))
+		}
+		if strings.Join(reasons, "|") != strings.Join(expectedReasons, "|") {
+			t.Errorf("%s: expected stripped %q, got %q", test.Name, expectedReasons, reasons)
+		}
+	}
+}
+
+func TestGutenbergStrippedPositions(t *testing.T) {
+	document, err := flesch.ParseString(gutenbergSample, "story.txt", flesch.WithGutenbergStripping(), flesch.WithHeadingStripping())
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	header, footer := document.Stripped[0], document.Stripped[len(document.Stripped)-1]
+	if header.Start.String() != "1:1" || header.End.String() != "11:19" {
+		t.Errorf("expected the header at 1:1-11:19, got %s-%s", header.Start, header.End)
+	}
+	if footer.Start.String() != "33:1" || footer.End.String() != "37:33" {
+		t.Errorf("expected the footer at 33:1-37:33, got %s-%s", footer.Start, footer.End)
+	}
+	dog := document.Sentences[2].Words[1]
+	if dog.String() != "dog" || dog.StartPos.String() != "31:5" {
+		t.Errorf("expected dog at 31:5, got %q at %s", dog, dog.StartPos)
+	}
+}
+
+func TestHeadingStrippingKeepsProse(t *testing.T) {
+	filename := path.Join("..", "MobyDick.txt")
+	plain, err := flesch.ParseFile(filename)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	stripped, err := flesch.ParseFile(filename, flesch.WithGutenbergStripping(), flesch.WithHeadingStripping())
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	// the bundled text has no Gutenberg header, but a heading for each
+	// chapter
+	var headings int
+	for _, r := range stripped.Stripped {
+		if r.Reason != flesch.StripHeading {
+			t.Errorf("unexpected %s stripped at %s", r.Reason, r.Start)
+		}
+		headings++
+	}
+	if headings < 135 {
+		t.Errorf("expected a heading per chapter, got %d", headings)
+	}
+	if stripped.WordCount() >= plain.WordCount() || stripped.WordCount() < plain.WordCount()*99/100 {
+		t.Errorf("expected a few words fewer than %d, got %d", plain.WordCount(), stripped.WordCount())
+	}
+}
@@ -0,0 +1,284 @@
+package flesch
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/net/html"
+	"io"
+	"strings"
+)
+
+// hiddenElements hold no visible prose, or none worth scoring.
+var hiddenElements = map[string]bool{
+	"canvas": true, "code": true, "head": true, "iframe": true, "nav": true,
+	"noscript": true, "script": true, "style": true, "svg": true,
+	"template": true, "title": true,
+}
+
+// blockElements start and end sentences.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"caption": true, "dd": true, "details": true, "dialog": true, "div": true,
+	"dl": true, "dt": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hgroup": true, "hr": true, "legend": true, "li": true, "main": true,
+	"ol": true, "option": true, "p": true, "pre": true, "section": true,
+	"summary": true, "table": true, "tbody": true, "td": true, "tfoot": true,
+	"th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// voidElements never have an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// element is an open element of an HTML page.
+type element struct {
+	tag      string
+	id       string
+	classes  []string
+	hidden   bool
+	inRegion bool
+	// textStart is where the element's extracted text starts
+	textStart int
+}
+
+// extractHTML extracts the visible prose of an HTML page. The contents
+// of script, style, nav, code and other hidden elements are left out,
+// block elements like paragraphs, list items, headings and table cells
+// end sentences, and character references are decoded. With a region
+// selector, only the prose of matching elements is kept.
+func extractHTML(source []byte, c config) (*prose, error) {
+	var region selector
+	if c.htmlRegion != "" {
+		var err error
+		if region, err = parseSelector(c.htmlRegion); err != nil {
+			return nil, err
+		}
+	}
+	p := newProse(string(source))
+	root := element{inRegion: region == nil}
+	stack := []element{root}
+	var matched bool
+	tokenizer := html.NewTokenizer(bytes.NewReader(source))
+	var offset int
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			break
+		}
+		start := offset
+		offset += len(tokenizer.Raw())
+		parent := stack[len(stack)-1]
+
+		switch tokenType {
+		case html.TextToken:
+			if !parent.hidden && parent.inRegion {
+				p.copyText(start, offset)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttributes := tokenizer.TagName()
+			e := element{tag: string(name), hidden: parent.hidden || hiddenElements[string(name)]}
+			for hasAttributes {
+				var key, value []byte
+				key, value, hasAttributes = tokenizer.TagAttr()
+				switch string(key) {
+				case "id":
+					e.id = string(value)
+				case "class":
+					e.classes = strings.Fields(string(value))
+				case "hidden":
+					e.hidden = true
+				}
+			}
+			if blockElements[e.tag] {
+				p.endBlock(start)
+			}
+			e.textStart = p.text.Len()
+			if e.tag == "br" && !parent.hidden && parent.inRegion {
+				p.insert("\n", start)
+			}
+			if voidElements[e.tag] || tokenType == html.SelfClosingTagToken {
+				continue
+			}
+			stack = append(stack, e)
+			e.inRegion = parent.inRegion || region.matches(stack)
+			matched = matched || e.inRegion
+			stack[len(stack)-1] = e
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			// end tags close any elements left open inside them
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == string(name) {
+					if isHeadingTag(stack[i].tag) {
+						p.markHeading(stack[i].textStart)
+					}
+					stack = stack[:i]
+					break
+				}
+			}
+			if blockElements[string(name)] {
+				p.endBlock(start)
+			}
+		}
+	}
+	p.endBlock(len(source))
+	if region != nil && !matched {
+		return nil, fmt.Errorf("no element matches %q", c.htmlRegion)
+	}
+
+	return p, nil
+}
+
+func isHeadingTag(tag string) bool {
+	return len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6'
+}
+
+// copyText appends source[start:end] to the extracted text, decoding
+// character references.
+func (p *prose) copyText(start, end int) {
+	copied := start
+	for i := start; i < end; i++ {
+		if p.source[i] != '&' {
+			continue
+		}
+		decoded, n := entityAt(p.source, i, end)
+		if n == 0 {
+			continue
+		}
+		p.copy(copied, i)
+		p.insert(decoded, i)
+		copied = i + n
+		i = copied - 1
+	}
+	p.copy(copied, end)
+}
+
+// entityAt decodes the character reference, like "&amp;" or "&#8212;",
+// at src[i], returning its length in src, or 0 if there is none.
+func entityAt(src string, i, end int) (string, int) {
+	semicolon := strings.IndexByte(src[i:end], ';')
+	if semicolon < 2 || semicolon > 32 {
+		return "", 0
+	}
+	entity := src[i : i+semicolon+1]
+	decoded := html.UnescapeString(entity)
+	if decoded == entity {
+		return "", 0
+	}
+
+	return decoded, len(entity)
+}
+
+// selector is a CSS selector of the simple kind that picks out a region
+// of a page, like "main", "article.post" or "#content .body": comma
+// separated alternatives of type, id and class selectors combined with
+// descendant combinators.
+type selector [][]compoundSelector
+
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+func parseSelector(text string) (selector, error) {
+	var sel selector
+	for _, alternative := range strings.Split(text, ",") {
+		var compounds []compoundSelector
+		for _, part := range strings.Fields(alternative) {
+			compound, err := parseCompoundSelector(part)
+			if err != nil {
+				return nil, fmt.Errorf("selector %q: %w", text, err)
+			}
+			compounds = append(compounds, compound)
+		}
+		if len(compounds) == 0 {
+			return nil, fmt.Errorf("selector %q: empty alternative", text)
+		}
+		sel = append(sel, compounds)
+	}
+
+	return sel, nil
+}
+
+func parseCompoundSelector(text string) (compoundSelector, error) {
+	var compound compoundSelector
+	if strings.ContainsAny(text, ">+~:[]()") {
+		return compound, fmt.Errorf("unsupported syntax in %q", text)
+	}
+	end := strings.IndexAny(text, "#.")
+	if end < 0 {
+		end = len(text)
+	}
+	compound.tag = strings.ToLower(text[:end])
+	if compound.tag == "*" {
+		compound.tag = ""
+	}
+	for text = text[end:]; text != ""; {
+		kind := text[0]
+		end := strings.IndexAny(text[1:], "#.")
+		if end < 0 {
+			end = len(text) - 1
+		}
+		name := text[1 : end+1]
+		if name == "" {
+			return compound, fmt.Errorf("missing name after %q", kind)
+		}
+		if kind == '#' {
+			compound.id = name
+		} else {
+			compound.classes = append(compound.classes, name)
+		}
+		text = text[end+1:]
+	}
+
+	return compound, nil
+}
+
+// matches reports whether the last element of stack, whose ancestors are
+// the elements before it, is selected.
+func (sel selector) matches(stack []element) bool {
+	for _, compounds := range sel {
+		last := len(compounds) - 1
+		if !compounds[last].matches(stack[len(stack)-1]) {
+			continue
+		}
+		ancestor := len(stack) - 2
+		// the root of the stack is not an element
+		for c := last - 1; c >= 0 && ancestor > 0; ancestor-- {
+			if compounds[c].matches(stack[ancestor]) {
+				last = c
+				c--
+			}
+		}
+		if last == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c compoundSelector) matches(e element) bool {
+	if c.tag != "" && c.tag != e.tag || c.id != "" && c.id != e.id {
+		return false
+	}
+	for _, class := range c.classes {
+		var found bool
+		for _, elementClass := range e.classes {
+			found = found || elementClass == class
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+const htmlSample = `<!DOCTYPE html>
+<html>
+<head><title>Not prose</title><style>p { color: red; }</style></head>
+<body>
+<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+<main>
+<h1>Welcome home</h1>
+<p>Fish &amp; chips cost &pound;5. Run <code>make all</code> first.</p>
+<ul><li>One item<li>Another item</ul>
+<table><tr><td>Cell one</td><td>Cell two</td></tr></table>
+<script>var x = "Not prose either.";</script>
+</main>
+<footer><p hidden>Hidden text.</p><p>Footer text.</p></footer>
+</body>
+</html>
+`
+
+func TestHTMLSentences(t *testing.T) {
+	sentences := sentenceStrings(t, htmlSample, flesch.WithFormat(flesch.FormatHTML))
+	expected := []string{
+		"Welcome home",
+		"Fish & chips cost £5.",
+		"Run  first.",
+		"One item",
+		"Another item",
+		"Cell one",
+		"Cell two",
+		"Footer text.",
+	}
+	if strings.Join(sentences, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, sentences)
+	}
+}
+
+func TestHTMLRegion(t *testing.T) {
+	sentences := sentenceStrings(t, htmlSample, flesch.WithFormat(flesch.FormatHTML), flesch.WithHTMLRegion("main ul, footer"))
+	expected := []string{"One item", "Another item", "Footer text."}
+	if strings.Join(sentences, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, sentences)
+	}
+
+	_, err := flesch.ParseString(htmlSample, "page", flesch.WithFormat(flesch.FormatHTML), flesch.WithHTMLRegion("article"))
+	if err == nil {
+		t.Error("expected an error for a region matching nothing")
+	}
+	_, err = flesch.ParseString(htmlSample, "page", flesch.WithFormat(flesch.FormatHTML), flesch.WithHTMLRegion("main > p"))
+	if err == nil {
+		t.Error("expected an error for an unsupported selector")
+	}
+}
+
+func TestHTMLPositions(t *testing.T) {
+	document, err := flesch.ParseString(htmlSample, "page.html", flesch.WithFormat(flesch.FormatHTML))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	chips := document.Sentences[1].Words[1]
+	if chips.String() != "chips" || chips.StartPos.String() != "8:15" {
+		t.Errorf("expected chips at 8:15, got %q at %s", chips, chips.StartPos)
+	}
+	if !strings.HasPrefix(htmlSample[chips.StartPos.Offset:], "chips") {
+		t.Errorf("expected chips at byte %d", chips.StartPos.Offset)
+	}
+}
+
+func TestHTMLPositionsOnOneLine(t *testing.T) {
+	// minified HTML, with every sentence on a single line
+	var page strings.Builder
+	for i := 0; i < 2000; i++ {
+		page.WriteString("<p>Fish &amp; chips are tasty.</p>")
+	}
+	document, err := flesch.ParseString(page.String(), "minified.html", flesch.WithFormat(flesch.FormatHTML))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	const paragraph = len("<p>Fish &amp; chips are tasty.</p>")
+	last := document.Sentences[len(document.Sentences)-1]
+	tasty := last.Words[len(last.Words)-1]
+	column := 1999*paragraph + len("<p>Fish &amp; chips are ") + 1
+	if tasty.StartPos.Line != 1 || tasty.StartPos.Column != column {
+		t.Errorf("expected tasty at 1:%d, got %s", column, tasty.StartPos)
+	}
+}
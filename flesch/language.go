@@ -0,0 +1,231 @@
+package flesch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Language describes how to tokenize and score text written in a
+// language: which letters are vowels, how syllables are counted and
+// which variant of the Flesch Reading Ease Score applies.
+type Language struct {
+	// Code is the ISO 639-1 code of the language, e.g. "es"
+	Code string
+	Name string
+	// Vowels are the lowercase vowels of the language, accented
+	// forms included
+	Vowels string
+	// Counter counts syllables in words of the language
+	Counter SyllableCounter
+	// Ease is the language's Flesch Reading Ease formula
+	Ease func(Counts) float32
+}
+
+// TypeOfRune classifies a rune for the language. Letters that are not
+// vowels of the language are consonants, whatever their script.
+func (l *Language) TypeOfRune(r rune) RuneType {
+	return typeOfRune(r, l.Vowels)
+}
+
+func typeOfRune(r rune, vowels string) RuneType {
+	if !unicode.IsLetter(r) {
+		return typeOfNonLetter(r)
+	}
+	if isVowel(r, vowels) {
+		return RuneTypeVowel
+	}
+
+	return RuneTypeConsonant
+}
+
+// isVowel reports whether a letter is one of the given vowels. An
+// accented Latin vowel that is not listed separately is a vowel if
+// its base letter is.
+func isVowel(r rune, vowels string) bool {
+	r = unicode.ToLower(r)
+	if strings.ContainsRune(vowels, r) {
+		return true
+	}
+	base, accented := accentedVowels[r]
+
+	return accented && strings.ContainsRune(vowels, base)
+}
+
+// accentedVowels maps accented Latin vowels to their base letter.
+var accentedVowels = func() map[rune]rune {
+	table := make(map[rune]rune)
+	for _, forms := range []string{
+		"aàáâãäåāăąǎ", "aæ", "eèéêëēĕėęě", "iìíîïĩīĭįǐ",
+		"oòóôõöøōŏőǒ", "oœ", "uùúûüũūŭůűųǔ", "yýÿŷ",
+	} {
+		base := []rune(forms)[0]
+		for _, r := range []rune(forms)[1:] {
+			table[r] = base
+		}
+	}
+
+	return table
+}()
+
+const englishVowels = "aeiou"
+
+var English = &Language{
+	Code:    "en",
+	Name:    "English",
+	Vowels:  englishVowels,
+	Counter: HeuristicCounter,
+	Ease:    Counts.Score,
+}
+
+var Spanish = &Language{
+	Code:    "es",
+	Name:    "Spanish",
+	Vowels:  "aeiouáéíóúü",
+	Counter: newVowelGroupCounter("aeiouáéíóúü", spanishNuclei(), nil),
+	Ease:    Counts.FernandezHuerta,
+}
+
+var German = &Language{
+	Code:   "de",
+	Name:   "German",
+	Vowels: "aeiouyäöü",
+	Counter: newVowelGroupCounter("aeiouyäöü", []string{
+		"aa", "ai", "au", "ay", "äu", "ee", "ei", "eu", "ey", "ie", "oo",
+	}, nil),
+	Ease: Counts.Amstad,
+}
+
+var French = &Language{
+	Code:   "fr",
+	Name:   "French",
+	Vowels: "aeiouyàâæèéêëîïôœùûü",
+	Counter: newVowelGroupCounter("aeiouyàâæèéêëîïôœùûü", []string{
+		"eau", "aie", "œu", "ée", "ai", "aî", "au", "ei", "eu", "oi", "oî", "ou", "où", "oû", "ue", "ui",
+	}, []string{"es", "e"}),
+	Ease: Counts.KandelMoles,
+}
+
+var Dutch = &Language{
+	Code:   "nl",
+	Name:   "Dutch",
+	Vowels: "aeiouyáéëïó",
+	Counter: newVowelGroupCounter("aeiouyáéëïó", []string{
+		"aai", "eeu", "ieu", "oei", "ooi",
+		"aa", "au", "ee", "ei", "eu", "ie", "oe", "oo", "ou", "ui", "uu",
+	}, nil),
+	Ease: Counts.Douma,
+}
+
+var Italian = &Language{
+	Code:   "it",
+	Name:   "Italian",
+	Vowels: "aeiouàèéìíòóù",
+	Counter: newVowelGroupCounter("aeiouàèéìíòóù", []string{
+		"iei", "iuo", "uoi",
+		"ai", "au", "ei", "eu", "ia", "ie", "io", "iu", "oi", "ua", "ue", "ui", "uo",
+		"iò", "iù", "uò", "iè", "uè",
+	}, nil),
+	Ease: Counts.FleschVacca,
+}
+
+var languages = []*Language{English, Spanish, German, French, Dutch, Italian}
+
+// Languages lists the supported languages.
+func Languages() []*Language {
+	list := make([]*Language, len(languages))
+	copy(list, languages)
+
+	return list
+}
+
+// LookupLanguage finds a supported language by its code or name,
+// ignoring case.
+func LookupLanguage(codeOrName string) (*Language, bool) {
+	for _, l := range languages {
+		if strings.EqualFold(l.Code, codeOrName) || strings.EqualFold(l.Name, codeOrName) {
+			return l, true
+		}
+	}
+
+	return nil, false
+}
+
+// spanishNuclei lists the Spanish diphthongs and triphthongs. A weak vowel
+// (i, u, ü) next to a strong one forms a diphthong unless it is accented,
+// as in "país", which is a hiatus.
+func spanishNuclei() []string {
+	const strong = "aeoáéó"
+	const weak = "iuü"
+	nuclei := []string{"iu", "ui", "üi"}
+	for _, s := range strong {
+		for _, w := range weak {
+			nuclei = append(nuclei, string(w)+string(s))
+			if w != 'ü' {
+				nuclei = append(nuclei, string(s)+string(w))
+			}
+			for _, w2 := range "iy" {
+				nuclei = append(nuclei, string(w)+string(s)+string(w2))
+			}
+		}
+	}
+
+	return nuclei
+}
+
+// vowelGroupCounter counts a syllable for each vowel, except that the
+// diphthongs and triphthongs of a language count as a single syllable.
+// Muted endings, like the final "e" of French words, are not counted.
+type vowelGroupCounter struct {
+	vowels string
+	nuclei []string
+	muted  []string
+}
+
+func newVowelGroupCounter(vowels string, nuclei []string, muted []string) vowelGroupCounter {
+	// match the longest nucleus first
+	sort.SliceStable(nuclei, func(i, j int) bool {
+		return utf8.RuneCountInString(nuclei[i]) > utf8.RuneCountInString(nuclei[j])
+	})
+
+	return vowelGroupCounter{vowels: vowels, nuclei: nuclei, muted: muted}
+}
+
+func (c vowelGroupCounter) CountSyllables(word string) int {
+	word = strings.ToLower(word)
+	var syllables int
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRuneInString(word[i:])
+		if !strings.ContainsRune(c.vowels, r) {
+			i += size
+			continue
+		}
+		syllables++
+		for _, nucleus := range c.nuclei {
+			if strings.HasPrefix(word[i:], nucleus) {
+				size = len(nucleus)
+				break
+			}
+		}
+		i += size
+	}
+	for _, ending := range c.muted {
+		stem := strings.TrimSuffix(word, ending)
+		if stem == word || stem == "" {
+			continue
+		}
+		last, _ := utf8.DecodeLastRuneInString(stem)
+		if !strings.ContainsRune(c.vowels, last) && syllables > 1 {
+			syllables--
+		}
+		break
+	}
+
+	// all words are at least one syllable
+	if syllables == 0 {
+		syllables = 1
+	}
+
+	return syllables
+}
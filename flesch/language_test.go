@@ -0,0 +1,80 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"testing"
+)
+
+func TestLanguageSyllables(t *testing.T) {
+	testCases := map[*flesch.Language][]SyllableTestResult{
+		flesch.Spanish: {{"canción", 2}, {"país", 2}, {"ciudad", 2}, {"Paraguay", 3}, {"queso", 2}},
+		flesch.German:  {{"Häuser", 2}, {"Freiheit", 2}, {"Zeitung", 2}, {"Theater", 3}},
+		flesch.French:  {{"château", 2}, {"livres", 1}, {"idée", 2}, {"maison", 2}},
+		flesch.Dutch:   {{"ooievaar", 3}, {"huis", 1}, {"fiets", 1}, {"beleid", 2}},
+		flesch.Italian: {{"famiglia", 3}, {"perché", 2}, {"uomo", 2}, {"città", 2}},
+	}
+	for language, tests := range testCases {
+		for _, test := range tests {
+			result := language.Counter.CountSyllables(test.Word)
+			if test.Expected != result {
+				t.Errorf("%s %s: expected %d syllables, got %d", language.Name, test.Word, test.Expected, result)
+			}
+		}
+	}
+}
+
+func TestAccentedWords(t *testing.T) {
+	document, err := flesch.ParseString("Él cantó una canción.", "spanish", flesch.WithLanguage(flesch.Spanish))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var words []string
+	for _, w := range document.Words() {
+		words = append(words, w.String())
+	}
+	expected := []string{"Él", "cantó", "una", "canción"}
+	if len(words) != len(expected) {
+		t.Fatalf("expected words %v, got %v", expected, words)
+	}
+	for i := range expected {
+		if words[i] != expected[i] {
+			t.Errorf("expected word %s, got %s", expected[i], words[i])
+		}
+	}
+	if document.Syllables() != 7 {
+		t.Errorf("expected 7 syllables, got %d", document.Syllables())
+	}
+}
+
+func TestLanguageScore(t *testing.T) {
+	text := "El perro come. La casa es grande."
+	document, err := flesch.ParseString(text, "spanish", flesch.WithLanguage(flesch.Spanish))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if document.Language() != flesch.Spanish {
+		t.Errorf("expected the document to be Spanish, got %s", document.Language().Name)
+	}
+	if document.Score() != document.Counts().FernandezHuerta() {
+		t.Errorf("expected the Fernández-Huerta score %v, got %v", document.Counts().FernandezHuerta(), document.Score())
+	}
+
+	english, err := flesch.ParseString(text, "english")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if english.Score() != english.Counts().Score() {
+		t.Errorf("expected the English score %v, got %v", english.Counts().Score(), english.Score())
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	for _, key := range []string{"de", "German", "GERMAN"} {
+		if language, ok := flesch.LookupLanguage(key); !ok || language != flesch.German {
+			t.Errorf("expected %s to be German", key)
+		}
+	}
+	if _, ok := flesch.LookupLanguage("tlh"); ok {
+		t.Errorf("expected no language for tlh")
+	}
+}
@@ -0,0 +1,408 @@
+package flesch
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	atxHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+|$)`)
+	closingHashes  = regexp.MustCompile(`[ \t]+#+[ \t]*$|^#+[ \t]*$`)
+	listMarker     = regexp.MustCompile(`^[ \t]*(?:[-*+]|\d{1,9}[.)])(?:[ \t]+|$)(?:\[[ xX]\][ \t]+)?`)
+	thematicBreak  = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	setextLine     = regexp.MustCompile(`^ {0,3}(?:=+|-+)[ \t]*$`)
+	blockquote     = regexp.MustCompile(`^ {0,3}>[ \t]?`)
+	linkDefinition = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:[ \t]*\S`)
+	tableDelimiter = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	codeFence      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	rawHTMLBlock   = regexp.MustCompile(`(?i)^ {0,3}<(pre|script|style|textarea)[\s>]`)
+)
+
+// extractMarkdown extracts the prose of a Markdown document. Code blocks,
+// inline code, images, HTML, link targets, front matter and the syntax of
+// headings, lists, quotes and tables are left out. Headings, list items
+// and table cells end a sentence, whether or not they end with a period.
+func extractMarkdown(source []byte, c config) (*prose, error) {
+	md := markdown{prose: newProse(string(source))}
+	md.blocks()
+
+	return md.prose, nil
+}
+
+type markdown struct {
+	*prose
+}
+
+// blocks walks the document a line at a time, skipping what is not prose
+// and handing the rest to inline.
+func (m markdown) blocks() {
+	src := m.source
+	lines := m.lines()
+	var fence string         // the fence of the code block being skipped
+	var closingTag string    // the closing tag of the raw HTML being skipped
+	var inParagraph bool     // whether the previous line was prose
+	var inList, inTable bool // whether a list or table is open
+	var inComment bool       // whether an HTML comment is open
+	var paragraphStart int   // where the extracted paragraph starts
+	for n := 0; n < len(lines); n++ {
+		start, end := lines[n][0], lines[n][1]
+		line := src[start:end]
+
+		if n == 0 && (line == "---" || line == "+++") {
+			n = skipFrontMatter(src, lines, line)
+			continue
+		}
+		if fence != "" {
+			if isClosingFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if inComment || closingTag != "" {
+			if inComment && strings.Contains(line, "-->") {
+				inComment = false
+			}
+			if closingTag != "" && strings.Contains(strings.ToLower(line), closingTag) {
+				closingTag = ""
+			}
+			continue
+		}
+
+		for {
+			prefix := blockquote.FindStringIndex(line)
+			if prefix == nil {
+				break
+			}
+			start += prefix[1]
+			line = src[start:end]
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			m.endBlock(start)
+			inParagraph, inTable = false, false
+			continue
+		case indentation(line) >= 4 && !inParagraph && !inList:
+			// an indented code block
+			continue
+		case codeFence.MatchString(line):
+			m.endBlock(start)
+			fence = codeFence.FindStringSubmatch(line)[1]
+			inParagraph = false
+			continue
+		case strings.HasPrefix(trimmed, "<!--"):
+			m.endBlock(start)
+			inComment = !strings.Contains(trimmed, "-->")
+			inParagraph = false
+			continue
+		case rawHTMLBlock.MatchString(line):
+			m.endBlock(start)
+			tag := strings.ToLower(rawHTMLBlock.FindStringSubmatch(line)[1])
+			if !strings.Contains(strings.ToLower(line), "</"+tag+">") {
+				closingTag = "</" + tag + ">"
+			}
+			inParagraph = false
+			continue
+		case inParagraph && !inTable && setextLine.MatchString(line):
+			// the paragraph above was a heading
+			m.markHeading(paragraphStart)
+			m.endBlock(start)
+			inParagraph = false
+			continue
+		case thematicBreak.MatchString(line):
+			m.endBlock(start)
+			inParagraph, inList = false, false
+			continue
+		case linkDefinition.MatchString(line):
+			continue
+		case inTable && tableDelimiter.MatchString(line) && strings.Contains(line, "|"):
+			continue
+		case strings.Contains(line, "|") && (inTable || n+1 < len(lines) && isTableDelimiter(src, lines[n+1])):
+			m.endBlock(start)
+			m.tableRow(start, end)
+			inParagraph, inTable = false, true
+			continue
+		}
+
+		if heading := atxHeading.FindStringIndex(line); heading != nil {
+			m.endBlock(start)
+			content := start + heading[1]
+			contentEnd := end
+			if hashes := closingHashes.FindStringIndex(src[content:end]); hashes != nil {
+				contentEnd = content + hashes[0]
+			}
+			headingStart := m.text.Len()
+			m.inline(content, contentEnd)
+			m.markHeading(headingStart)
+			m.endBlock(end)
+			inParagraph = false
+			continue
+		}
+		if marker := listMarker.FindStringIndex(line); marker != nil {
+			m.endBlock(start)
+			start += marker[1]
+			inList = true
+		} else if indentation(line) == 0 && !inParagraph {
+			inList = false
+		}
+
+		if !inParagraph {
+			paragraphStart = m.text.Len()
+		}
+		m.inline(start+len(line)-len(strings.TrimLeft(line, " \t")), end)
+		// keep the line break, so positions stay on their lines
+		if end < len(src) {
+			m.copy(end, end+1)
+		}
+		inParagraph = true
+	}
+	m.endBlock(len(src))
+}
+
+// skipFrontMatter returns the index of the line that closes the YAML or
+// TOML front matter opened by the first line.
+func skipFrontMatter(src string, lines [][2]int, fence string) int {
+	for n := 1; n < len(lines); n++ {
+		line := src[lines[n][0]:lines[n][1]]
+		if line == fence || fence == "---" && line == "..." {
+			return n
+		}
+	}
+
+	// no front matter after all, just a thematic break
+	return 0
+}
+
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return indentation(line) < 4 &&
+		strings.HasPrefix(trimmed, fence) &&
+		strings.Trim(trimmed, fence[:1]) == ""
+}
+
+func isTableDelimiter(src string, line [2]int) bool {
+	text := src[line[0]:line[1]]
+
+	return strings.Contains(text, "|") && tableDelimiter.MatchString(text)
+}
+
+// indentation measures the leading whitespace of a line in columns,
+// with tab stops every four.
+func indentation(line string) int {
+	var columns int
+	for _, r := range line {
+		switch r {
+		case ' ':
+			columns++
+		case '\t':
+			columns += 4 - columns%4
+		default:
+			return columns
+		}
+	}
+
+	return columns
+}
+
+// tableRow extracts each cell of a table row as a block of its own.
+func (m markdown) tableRow(start, end int) {
+	row := m.source[start:end]
+	cell := 0
+	inCode := false
+	for i := 0; i <= len(row); i++ {
+		if i < len(row) {
+			switch {
+			case row[i] == '\\':
+				i++
+				continue
+			case row[i] == '`':
+				inCode = !inCode
+				continue
+			case row[i] != '|' || inCode:
+				continue
+			}
+		}
+		m.inline(start+cell, start+i)
+		m.endBlock(start + i)
+		cell = i + 1
+	}
+}
+
+// inline extracts the prose of source[start:end], leaving out code spans,
+// images, HTML tags, autolinks, link targets and emphasis markers, and
+// decoding escapes and entities.
+func (m markdown) inline(start, end int) {
+	src := m.source
+	copied := start
+	skip := func(i, to int) {
+		m.copy(copied, i)
+		copied = to
+	}
+	for i := start; i < end; {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < end && isASCIIPunctuation(src[i+1]):
+			// the escaped character starts the next verbatim run
+			skip(i, i+1)
+			i += 2
+		case c == '`':
+			run := i + len(src[i:end]) - len(strings.TrimLeft(src[i:end], "`"))
+			closing := closingBackticks(src[run:end], run-i)
+			if closing < 0 {
+				i = run
+				continue
+			}
+			skip(i, run+closing+run-i)
+			i = copied
+		case c == '!' && i+1 < end && src[i+1] == '[':
+			_, _, after, ok := linkAt(src, i+1, end)
+			if !ok {
+				i++
+				continue
+			}
+			skip(i, after)
+			i = after
+		case c == '[':
+			textStart, textEnd, after, ok := linkAt(src, i, end)
+			if !ok {
+				i++
+				continue
+			}
+			skip(i, after)
+			m.inline(textStart, textEnd)
+			i = after
+		case c == '<':
+			after := tagEnd(src, i, end)
+			if after < 0 {
+				i++
+				continue
+			}
+			skip(i, after)
+			i = after
+		case c == '*' || c == '~' && i+1 < end && src[i+1] == '~' || c == '_' && isEmphasisUnderscore(src, i, start, end):
+			after := i + len(src[i:end]) - len(strings.TrimLeft(src[i:end], string(c)))
+			skip(i, after)
+			i = after
+		case c == '&':
+			decoded, n := entityAt(src, i, end)
+			if n == 0 {
+				i++
+				continue
+			}
+			skip(i, i+n)
+			m.insert(decoded, i)
+			i = copied
+		default:
+			i++
+		}
+	}
+	m.copy(copied, end)
+}
+
+// closingBackticks finds the run of exactly n backticks that closes a
+// code span in text, or -1.
+func closingBackticks(text string, n int) int {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+		if run == n {
+			return i
+		}
+		i += run
+	}
+
+	return -1
+}
+
+// linkAt parses an inline, reference or footnote link whose text opens at
+// src[open], returning the bounds of the text and the end of the link.
+// Footnote references have no text.
+func linkAt(src string, open, end int) (textStart, textEnd, after int, ok bool) {
+	closing := matching(src, open, end, '[', ']')
+	if closing < 0 {
+		return 0, 0, 0, false
+	}
+	textStart, textEnd = open+1, closing
+	if strings.HasPrefix(src[textStart:textEnd], "^") {
+		return textStart, textStart, closing + 1, true
+	}
+	if closing+1 >= end {
+		return 0, 0, 0, false
+	}
+	switch src[closing+1] {
+	case '(':
+		after = matching(src, closing+1, end, '(', ')')
+	case '[':
+		after = matching(src, closing+1, end, '[', ']')
+	default:
+		return 0, 0, 0, false
+	}
+	if after < 0 {
+		return 0, 0, 0, false
+	}
+
+	return textStart, textEnd, after + 1, true
+}
+
+// matching finds the bracket that closes the one at src[open], or -1.
+func matching(src string, open, end int, opening, closing byte) int {
+	depth := 0
+	for i := open; i < end; i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case opening:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// tagEnd returns the end of the HTML tag, comment or autolink that opens
+// at src[i], or -1 if there is none.
+func tagEnd(src string, i, end int) int {
+	if i+1 >= end {
+		return -1
+	}
+	next, _ := utf8.DecodeRuneInString(src[i+1 : end])
+	if !unicode.IsLetter(next) && next != '/' && next != '!' {
+		return -1
+	}
+	closing := strings.IndexByte(src[i:end], '>')
+	if closing < 0 {
+		return -1
+	}
+
+	return i + closing + 1
+}
+
+// isEmphasisUnderscore reports whether the underscore at src[i] marks
+// emphasis rather than joining the words of an identifier like snake_case.
+func isEmphasisUnderscore(src string, i, start, end int) bool {
+	run := i + len(src[i:end]) - len(strings.TrimLeft(src[i:end], "_"))
+	before, after := ' ', ' '
+	if i > start {
+		before, _ = utf8.DecodeLastRuneInString(src[start:i])
+	}
+	if run < end {
+		after, _ = utf8.DecodeRuneInString(src[run:end])
+	}
+
+	return !isLetterOrNumber(before) || !isLetterOrNumber(after)
+}
+
+func isASCIIPunctuation(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
@@ -0,0 +1,103 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+const markdownSample = `---
+title: Sample
+---
+
+# Getting started
+
+Install the tool with ` + "`go install`" + ` and run it. See [the guide](https://example.com/guide "Guide") for more &amp; less.
+
+- First item
+- Second *item* with **bold**
+  continued here
+1. Numbered one
+
+` + "```go" + `
+fmt.Println("Code is not prose.")
+` + "```" + `
+
+| Name | Value |
+|------|-------|
+| Alpha | One two |
+
+> A quote that ends. Another one
+> spanning lines.
+
+Setext Heading
+==============
+
+![alt text](image.png) An image_name and snake_case word.<br>
+Footnote[^1] here.
+
+    indented code block
+`
+
+func TestMarkdownSentences(t *testing.T) {
+	sentences := sentenceStrings(t, markdownSample, flesch.WithFormat(flesch.FormatMarkdown))
+	expected := []string{
+		"Getting started",
+		"Install the tool with  and run it.",
+		"See the guide for more & less.",
+		"First item",
+		"Second item with bold\ncontinued here",
+		"Numbered one",
+		"Name",
+		"Value",
+		"Alpha",
+		"One two",
+		"A quote that ends.",
+		"Another one\nspanning lines.",
+		"Setext Heading",
+		"An image_name and snake_case word.",
+		"Footnote here.",
+	}
+	if strings.Join(sentences, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, sentences)
+	}
+}
+
+func TestMarkdownPositions(t *testing.T) {
+	document, err := flesch.ParseString(markdownSample, "sample.md", flesch.WithFormat(flesch.FormatMarkdown))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	// every word is found where the source has it
+	lines := strings.Split(markdownSample, "\n")
+	for _, w := range document.Words() {
+		if !strings.HasPrefix(markdownSample[w.StartPos.Offset:], w.String()) {
+			t.Errorf("expected %q at byte %d", w, w.StartPos.Offset)
+		}
+		line := []rune(lines[w.StartPos.Line-1])
+		if !strings.HasPrefix(string(line[w.StartPos.Column-1:]), w.String()) {
+			t.Errorf("expected %q at %s", w, w.StartPos)
+		}
+	}
+
+	guide := document.Sentences[2].Words[1]
+	if guide.String() != "the" || guide.StartPos.String() != "7:53" {
+		t.Errorf("expected the link text at 7:53, got %q at %s", guide, guide.StartPos)
+	}
+	continued := document.Sentences[4].Words[4]
+	if continued.String() != "continued" || continued.StartPos.String() != "11:3" {
+		t.Errorf("expected a list item's second line at 11:3, got %q at %s", continued, continued.StartPos)
+	}
+}
+
+func TestParseFileDetectsMarkdown(t *testing.T) {
+	document, err := flesch.ParseFile("../README.md")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	for _, w := range document.Words() {
+		if strings.ContainsAny(w.String(), "`#|[]()") {
+			t.Errorf("Markdown syntax %q scored as prose", w)
+		}
+	}
+}
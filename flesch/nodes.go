@@ -1,10 +1,17 @@
 package flesch
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 type Document struct {
 	Sentences []Sentence
-	name      string
+	// Stripped are the parts of plain text left out of scoring by
+	// WithGutenbergStripping or WithHeadingStripping
+	Stripped []StrippedRange
+	name     string
+	language *Language
 }
 
 func (d Document) Name() string {
@@ -15,6 +22,18 @@ func (d Document) Name() string {
 	return d.name
 }
 
+// WithCounter returns a copy of the document whose words count
+// their syllables with counter.
+func (d Document) WithCounter(counter SyllableCounter) Document {
+	sentences := make([]Sentence, len(d.Sentences))
+	for i, s := range d.Sentences {
+		sentences[i] = s.WithCounter(counter)
+	}
+	d.Sentences = sentences
+
+	return d
+}
+
 func (d Document) WordCount() int {
 	var count int
 	for _, sentence := range d.Sentences {
@@ -56,35 +75,41 @@ func (d Document) Syllables() int {
 	return count
 }
 
-func (d Document) Score() float32 {
-	score := 206.835 - (84.6 * d.avgSylPerWord()) - (1.015 * d.avgWordPerSen())
+// Counts totals the sentences, words and syllables of the document.
+func (d Document) Counts() Counts {
+	var counts Counts
+	for _, s := range d.Sentences {
+		counts.Add(s)
+	}
 
-	return score
+	return counts
 }
 
-func (d Document) Kincaid() float32 {
-	score := .39*d.avgWordPerSen() + 11.8*d.avgSylPerWord() - 15.59
+// Language is the language the document was parsed as.
+func (d Document) Language() *Language {
+	if d.language == nil {
+		return English
+	}
 
-	return score
+	return d.language
 }
 
-func (d Document) avgWordPerSen() float32 {
-	words := float32(d.WordCount())
-	sentences := float32(len(d.Sentences))
-
-	return words / sentences
+// Score is the Flesch Reading Ease Score, or the adaptation of it for
+// the document's language.
+func (d Document) Score() float32 {
+	return d.Language().Ease(d.Counts())
 }
 
-func (d Document) avgSylPerWord() float32 {
-	syllables := float32(d.Syllables())
-	words := float32(d.WordCount())
-
-	return syllables / words
+func (d Document) Kincaid() float32 {
+	return d.Counts().Kincaid()
 }
 
 func (d Document) ReadableScore() string {
+	return readableScore(d.Score())
+}
+
+func readableScore(score float32) string {
 	var scoreMessage string
-	score := d.Score()
 	switch {
 	case score <= 100 && score > 90:
 		scoreMessage = "5th grade "
@@ -110,15 +135,26 @@ func (d Document) ReadableScore() string {
 // Sentence is encountered whenever you find a word that
 // ends in a specific punctuation symbol: a period, question
 // mark, or exclamation point.
+// Start and End are the rune offsets of the first and last rune of
+// the sentence within the document, and StartPos and EndPos are their
+// line, column and byte offset. Only parsing computes positions;
+// GetSentence leaves them unset.
 type Sentence struct {
-	allRunes []rune
-	Start    int
-	End      int
-	Words    []Word
+	runes []rune
+	// paragraphStart is set on the first sentence of a paragraph, and
+	// heading on those of a heading the input format marks as one
+	paragraphStart bool
+	heading        bool
+	language       *Language
+	Start          int
+	End            int
+	StartPos       Position
+	EndPos         Position
+	Words          []Word
 }
 
 func (s Sentence) Runes() []rune {
-	return s.allRunes[s.Start : s.End+1]
+	return s.runes
 }
 
 func (s Sentence) String() string {
@@ -129,6 +165,18 @@ func (s Sentence) String() string {
 	return b.String()
 }
 
+// WithCounter returns a copy of the sentence whose words count their
+// syllables with counter.
+func (s Sentence) WithCounter(counter SyllableCounter) Sentence {
+	words := make([]Word, len(s.Words))
+	for i, w := range s.Words {
+		words[i] = w.WithCounter(counter)
+	}
+	s.Words = words
+
+	return s
+}
+
 func (s Sentence) Syllables() int {
 	var count int
 	for _, w := range s.Words {
@@ -138,17 +186,39 @@ func (s Sentence) Syllables() int {
 	return count
 }
 
+// Counts totals the words and syllables of the sentence.
+func (s Sentence) Counts() Counts {
+	var counts Counts
+	counts.Add(s)
+
+	return counts
+}
+
+// Score is the reading ease of the sentence on its own, with the
+// formula for the language it was parsed as.
+func (s Sentence) Score() float32 {
+	return Document{language: s.language}.Language().Ease(s.Counts())
+}
+
+// Kincaid is the grade level of the sentence on its own.
+func (s Sentence) Kincaid() float32 {
+	return s.Counts().Kincaid()
+}
+
 // Word is contiguous sequence of alphabetic characters.
-// Whitespace defines word boundaries.
+// Whitespace defines word boundaries. Start, End, StartPos and
+// EndPos locate the word within the document, like those of a Sentence.
 type Word struct {
-	allRunes []rune
+	runes    []rune
+	counter  SyllableCounter
 	Start    int
 	End      int
+	StartPos Position
+	EndPos   Position
 }
 
 func (w Word) Runes() []rune {
-
-	return w.allRunes[w.Start : w.End+1]
+	return w.runes
 }
 
 func (w Word) String() string {
@@ -159,17 +229,59 @@ func (w Word) String() string {
 	return b.String()
 }
 
-// Syllables are considered to have been encountered whenever
-// you detect a vowel at the start of a word or a vowel
+// Characters counts the letters and digits of the word.
+func (w Word) Characters() int {
+	var count int
+	for _, r := range w.Runes() {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// WithCounter returns a copy of the word that counts its
+// syllables with counter.
+func (w Word) WithCounter(counter SyllableCounter) Word {
+	w.counter = counter
+
+	return w
+}
+
+// Syllables are counted by the word's SyllableCounter. Unless
+// one was given, they are considered to have been encountered
+// whenever you detect a vowel at the start of a word or a vowel
 // following a consonant in a word. A lone ‘e’ at the end
 // of a word does not count as a syllable. Three letter words
-// or less are always one syllable. One is the minimum.
+// or less are always one syllable. One is the minimum. The parts of a
+// hyphenated compound, like "mother-in-law", are counted as words of
+// their own and added up.
 func (w Word) Syllables() int {
+	if parts := strings.FieldsFunc(w.String(), isHyphen); len(parts) > 1 {
+		counter := w.syllableCounter()
+		var syllables int
+		for _, part := range parts {
+			syllables += counter.CountSyllables(part)
+		}
+		return syllables
+	}
+	if w.counter != nil {
+		return w.counter.CountSyllables(w.String())
+	}
 	word := w.Runes()
 
 	return syllablesFromRunes(word)
 }
 
+func (w Word) syllableCounter() SyllableCounter {
+	if w.counter == nil {
+		return HeuristicCounter
+	}
+
+	return w.counter
+}
+
 func syllablesFromRunes(runes []rune) int {
 	var syllables int
 	if len(runes) <= 3 {
@@ -211,105 +323,46 @@ func SyllablesFromString(word string) int {
 
 type RuneType int
 
+// TypeOfRune classifies a rune for English text. Classification is based
+// on Unicode categories, so letters of any script are vowels or consonants
+// and punctuation is recognized whatever its encoding. See
+// Language.TypeOfRune for other languages.
 func TypeOfRune(r rune) RuneType {
-	runeType, ok := runeLookup[r]
-	if !ok {
-		return RuneTypeOther
+	return typeOfRune(r, englishVowels)
+}
+
+// typeOfNonLetter classifies runes that are not letters, which is the
+// same for every language.
+//
+// Sentence stops are the sentence terminals of UAX #29 (periods, question
+// and exclamation marks in any script) and semicolons. Word stops are
+// dashes other than hyphens, brackets, opening and closing quotes (the
+// Ps, Pe, Pi and Pf categories), straight double quotes, commas and
+// colons. Combining marks and everything else are RuneTypeOther, which
+// continues a word without starting one.
+func typeOfNonLetter(r rune) RuneType {
+	switch {
+	case unicode.IsSpace(r):
+		return RuneTypeWhiteSpace
+	case unicode.IsNumber(r):
+		return RuneTypeNumber
+	case r == ';' || unicode.Is(unicode.Sentence_Terminal, r):
+		return RuneTypeSentenceStop
+	case unicode.Is(unicode.Dash, r) && !unicode.Is(unicode.Hyphen, r):
+		return RuneTypeWordStop
+	case unicode.In(r, unicode.Ps, unicode.Pe, unicode.Pi, unicode.Pf):
+		return RuneTypeWordStop
+	case strings.ContainsRune(wordStopPunctuation, r):
+		return RuneTypeWordStop
 	}
 
-	return runeType
-}
-
-var runeLookup = map[rune]RuneType{
-	// Numbers
-	'0': RuneTypeNumber,
-	'1': RuneTypeNumber,
-	'2': RuneTypeNumber,
-	'3': RuneTypeNumber,
-	'4': RuneTypeNumber,
-	'5': RuneTypeNumber,
-	'6': RuneTypeNumber,
-	'7': RuneTypeNumber,
-	'8': RuneTypeNumber,
-	'9': RuneTypeNumber,
-
-	// Lowercase
-	'a': RuneTypeVowel,
-	'b': RuneTypeConsonant,
-	'c': RuneTypeConsonant,
-	'd': RuneTypeConsonant,
-	'e': RuneTypeVowel,
-	'f': RuneTypeConsonant,
-	'g': RuneTypeConsonant,
-	'h': RuneTypeConsonant,
-	'i': RuneTypeVowel,
-	'j': RuneTypeConsonant,
-	'k': RuneTypeConsonant,
-	'l': RuneTypeConsonant,
-	'm': RuneTypeConsonant,
-	'n': RuneTypeConsonant,
-	'o': RuneTypeVowel,
-	'p': RuneTypeConsonant,
-	'q': RuneTypeConsonant,
-	'r': RuneTypeConsonant,
-	's': RuneTypeConsonant,
-	't': RuneTypeConsonant,
-	'u': RuneTypeVowel,
-	'v': RuneTypeConsonant,
-	'w': RuneTypeConsonant,
-	'x': RuneTypeConsonant,
-	'y': RuneTypeConsonant,
-	'z': RuneTypeConsonant,
-
-	// Uppercase
-	'A': RuneTypeVowel,
-	'B': RuneTypeConsonant,
-	'C': RuneTypeConsonant,
-	'D': RuneTypeConsonant,
-	'E': RuneTypeVowel,
-	'F': RuneTypeConsonant,
-	'G': RuneTypeConsonant,
-	'H': RuneTypeConsonant,
-	'I': RuneTypeVowel,
-	'J': RuneTypeConsonant,
-	'K': RuneTypeConsonant,
-	'L': RuneTypeConsonant,
-	'M': RuneTypeConsonant,
-	'N': RuneTypeConsonant,
-	'O': RuneTypeVowel,
-	'P': RuneTypeConsonant,
-	'Q': RuneTypeConsonant,
-	'R': RuneTypeConsonant,
-	'S': RuneTypeConsonant,
-	'T': RuneTypeConsonant,
-	'U': RuneTypeVowel,
-	'V': RuneTypeConsonant,
-	'W': RuneTypeConsonant,
-	'X': RuneTypeConsonant,
-	'Y': RuneTypeConsonant,
-	'Z': RuneTypeConsonant,
-
-	// Whitespace
-	' ':  RuneTypeWhiteSpace,
-	'\t': RuneTypeWhiteSpace,
-	'\n': RuneTypeWhiteSpace,
-	'\r': RuneTypeWhiteSpace,
-
-	// Word Stop
-	'”': RuneTypeWordStop,
-	'"': RuneTypeWordStop,
-	',': RuneTypeWordStop,
-	')': RuneTypeWordStop,
-	':': RuneTypeWordStop,
-	'—': RuneTypeWordStop,
-
-	// Sentence Stop
-	'.': RuneTypeSentenceStop,
-	';': RuneTypeSentenceStop,
-	'!': RuneTypeSentenceStop,
-	'?': RuneTypeSentenceStop,
+	return RuneTypeOther
 }
 
+// wordStopPunctuation lists the word stops among other punctuation (Po),
+// including the fullwidth and ideographic forms of commas and colons.
+const wordStopPunctuation = "\",:、，：＂"
+
 const (
 	RuneTypeSentenceStop RuneType = iota
 	RuneTypeWhiteSpace
@@ -6,7 +6,7 @@ import (
 )
 
 func TestTypeOfRune(t *testing.T) {
-	vowels := []string{"a", "e", "i", "o", "u", "A", "E", "I", "O", "U"}
+	vowels := []string{"a", "e", "i", "o", "u", "A", "E", "I", "O", "U", "é", "Ö", "æ"}
 	for _, vowel := range vowels {
 		r := []rune(vowel)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeVowel {
@@ -14,7 +14,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	consonants := []string{"b", "B", "L", "f", "T", "Q"}
+	consonants := []string{"b", "B", "L", "f", "T", "Q", "ñ", "ß", "Ж", "語"}
 	for _, consonant := range consonants {
 		r := []rune(consonant)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeConsonant {
@@ -22,7 +22,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	whitespace := []string{"\n", "\t", " ", "\r"}
+	whitespace := []string{"\n", "\t", " ", "\r", "\u00a0"}
 	for _, ws := range whitespace {
 		r := []rune(ws)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeWhiteSpace {
@@ -30,7 +30,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	wordStops := []string{"”", "\"", ",", ")"}
+	wordStops := []string{"”", "\"", ",", ")", "—", "–", "(", "“", "»"}
 	for _, ws := range wordStops {
 		r := []rune(ws)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeWordStop {
@@ -38,7 +38,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	numbers := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+	numbers := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "٣"}
 	for _, number := range numbers {
 		r := []rune(number)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeNumber {
@@ -46,7 +46,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	sentenceStop := []string{".", ";", "!", "?"}
+	sentenceStop := []string{".", ";", "!", "?", "。", "؟", "‼"}
 	for _, stop := range sentenceStop {
 		r := []rune(stop)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeSentenceStop {
@@ -54,7 +54,7 @@ func TestTypeOfRune(t *testing.T) {
 		}
 	}
 
-	others := []string{"語", "—"}
+	others := []string{"-", "'", "#", "\u0301"}
 	for _, stop := range others {
 		r := []rune(stop)[0]
 		if flesch.TypeOfRune(r) != flesch.RuneTypeOther {
@@ -85,3 +85,65 @@ func TestSyllablesFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestSentenceScores(t *testing.T) {
+	document, err := flesch.ParseString("The cat sat. Notwithstanding considerable disagreement, the administration characterized the reorganization as inevitable.", "sentences")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	easy, hard := document.Sentences[0], document.Sentences[1]
+	if easy.Score() != easy.Counts().Score() || easy.Kincaid() != easy.Counts().Kincaid() {
+		t.Errorf("expected the scores of the sentence's counts, got %.2f and %.2f", easy.Score(), easy.Kincaid())
+	}
+	if hard.Score() >= easy.Score() || hard.Kincaid() <= easy.Kincaid() {
+		t.Errorf("expected %q to be harder than %q", hard, easy)
+	}
+	if hard.Counts().Polysyllables != 7 {
+		t.Errorf("expected 7 polysyllables, got %d", hard.Counts().Polysyllables)
+	}
+
+	document, err = flesch.ParseString("El gato se sentó.", "oración", flesch.WithLanguage(flesch.Spanish))
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	sentence := document.Sentences[0]
+	if sentence.Score() != flesch.Spanish.Ease(sentence.Counts()) {
+		t.Errorf("expected the Spanish reading ease %.2f, got %.2f", flesch.Spanish.Ease(sentence.Counts()), sentence.Score())
+	}
+}
+
+func TestCompoundSyllables(t *testing.T) {
+	testCases := []struct {
+		Word       string
+		Heuristic  int
+		Dictionary int
+	}{
+		{"mother-in-law", 4, 4},
+		{"state-of-the-art", 4, 4},
+		// the vowel rules count "easy" as one syllable
+		{"free-and-easy", 3, 4},
+		{"well‐known", 2, 2},
+	}
+	for _, test := range testCases {
+		for _, c := range []struct {
+			name     string
+			options  []flesch.Option
+			expected int
+		}{
+			{"heuristic", nil, test.Heuristic},
+			{"dictionary", []flesch.Option{flesch.WithSyllableCounter(flesch.CMUCounter())}, test.Dictionary},
+		} {
+			document, err := flesch.ParseString("It was "+test.Word+".", "compound", c.options...)
+			if err != nil {
+				t.Fatalf("parsing: %s", err)
+			}
+			words := document.Words()
+			if len(words) != 3 || words[2].String() != test.Word {
+				t.Fatalf("%s: expected a single word, got %v", test.Word, words)
+			}
+			if syllables := words[2].Syllables(); syllables != c.expected {
+				t.Errorf("%s by %s: expected %d syllables, got %d", test.Word, c.name, c.expected, syllables)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package flesch
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+var zipMagic = []byte("PK\x03\x04")
+
+const (
+	wordNamespace    = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	compatNamespace  = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+	odfTextNamespace = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odfNamespace     = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odtMimeType      = "application/vnd.oasis.opendocument.text"
+)
+
+// officeVocabulary describes the XML of a word processor's document:
+// which elements are paragraphs, which of them are headings, which hold
+// their text, which stand for whitespace and which are left out along
+// with their content.
+type officeVocabulary struct {
+	paragraphs map[xml.Name]bool
+	headings   map[xml.Name]bool
+	// style is the element naming the style of a paragraph, for formats
+	// whose headings are paragraphs in a heading style
+	style xml.Name
+	// text is nil when paragraphs hold their text directly
+	text       map[xml.Name]bool
+	whitespace map[xml.Name]bool
+	skipped    map[xml.Name]bool
+}
+
+// docxVocabulary is that of word/document.xml. Tracked deletions and
+// moves are left out, and so are the fallbacks of alternate content,
+// which repeat what the preferred content says.
+var docxVocabulary = officeVocabulary{
+	paragraphs: map[xml.Name]bool{{Space: wordNamespace, Local: "p"}: true},
+	style:      xml.Name{Space: wordNamespace, Local: "pStyle"},
+	text:       map[xml.Name]bool{{Space: wordNamespace, Local: "t"}: true},
+	whitespace: map[xml.Name]bool{
+		{Space: wordNamespace, Local: "tab"}: true,
+		{Space: wordNamespace, Local: "br"}:  true,
+		{Space: wordNamespace, Local: "cr"}:  true,
+	},
+	skipped: map[xml.Name]bool{
+		{Space: wordNamespace, Local: "del"}:        true,
+		{Space: wordNamespace, Local: "moveFrom"}:   true,
+		{Space: compatNamespace, Local: "Fallback"}: true,
+	},
+}
+
+// odtVocabulary is that of content.xml. Comments, footnotes and tracked
+// changes, which hold the deleted text, are left out.
+var odtVocabulary = officeVocabulary{
+	paragraphs: map[xml.Name]bool{
+		{Space: odfTextNamespace, Local: "p"}: true,
+		{Space: odfTextNamespace, Local: "h"}: true,
+	},
+	headings: map[xml.Name]bool{{Space: odfTextNamespace, Local: "h"}: true},
+	whitespace: map[xml.Name]bool{
+		{Space: odfTextNamespace, Local: "s"}:          true,
+		{Space: odfTextNamespace, Local: "tab"}:        true,
+		{Space: odfTextNamespace, Local: "line-break"}: true,
+	},
+	skipped: map[xml.Name]bool{
+		{Space: odfNamespace, Local: "annotation"}:          true,
+		{Space: odfTextNamespace, Local: "note"}:            true,
+		{Space: odfTextNamespace, Local: "tracked-changes"}: true,
+	},
+}
+
+// extractDOCX extracts the paragraphs of the main document of a Word
+// file. Headers, footers, comments and footnotes are kept in other parts
+// of the file, and are not read.
+func extractDOCX(source []byte, c config) (*prose, error) {
+	archive, err := zip.NewReader(bytes.NewReader(source), int64(len(source)))
+	if err != nil {
+		return nil, err
+	}
+	document, err := openZipFile(archive, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer document.Close()
+
+	return extractOfficeXML(document, docxVocabulary)
+}
+
+// extractODT extracts the paragraphs and headings of an OpenDocument
+// text file. Headers and footers are kept with the styles, and are not
+// read.
+func extractODT(source []byte, c config) (*prose, error) {
+	archive, err := zip.NewReader(bytes.NewReader(source), int64(len(source)))
+	if err != nil {
+		return nil, err
+	}
+	content, err := openZipFile(archive, "content.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return extractOfficeXML(content, odtVocabulary)
+}
+
+// extractOfficeXML collects the text of each paragraph of a document.
+// Office documents have no plain text source for positions to point
+// into; instead, each paragraph is a line of the extracted text, so the
+// line of a position is the number of its paragraph.
+func extractOfficeXML(r io.Reader, vocabulary officeVocabulary) (*prose, error) {
+	p := newProse("")
+	decoder := xml.NewDecoder(r)
+	var paragraph strings.Builder
+	var inParagraph, inText int
+	var skipping int
+	var heading bool
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case skipping > 0 || vocabulary.skipped[t.Name]:
+				skipping++
+			case vocabulary.paragraphs[t.Name]:
+				inParagraph++
+				heading = vocabulary.headings[t.Name]
+			case t.Name == vocabulary.style && inParagraph > 0:
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" {
+						heading = isHeadingStyle(attr.Value)
+					}
+				}
+			case vocabulary.text[t.Name]:
+				inText++
+			case vocabulary.whitespace[t.Name] && inParagraph > 0:
+				paragraph.WriteByte(' ')
+			}
+		case xml.EndElement:
+			switch {
+			case skipping > 0:
+				skipping--
+			case vocabulary.paragraphs[t.Name]:
+				inParagraph--
+				p.writeParagraph(paragraph.String(), heading)
+				paragraph.Reset()
+			case vocabulary.text[t.Name]:
+				inText--
+			}
+		case xml.CharData:
+			if skipping == 0 && inParagraph > 0 && (vocabulary.text == nil || inText > 0) {
+				paragraph.Write(t)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// isHeadingStyle reports whether a paragraph style of a Word document,
+// named by its id, is one of the built-in heading styles.
+func isHeadingStyle(style string) bool {
+	return style == "Title" || strings.HasPrefix(style, "Heading")
+}
+
+func openZipFile(archive *zip.Reader, name string) (io.ReadCloser, error) {
+	for _, file := range archive.File {
+		if file.Name == name {
+			return file.Open()
+		}
+	}
+
+	return nil, fmt.Errorf("no %s in archive", name)
+}
+
+var errUnknownArchive = errors.New("not a DOCX, ODT or EPUB document")
+
+// archiveFormat identifies a zip archive by its contents.
+func archiveFormat(source []byte) (Format, error) {
+	archive, err := zip.NewReader(bytes.NewReader(source), int64(len(source)))
+	if err != nil {
+		return "", err
+	}
+	for _, file := range archive.File {
+		switch file.Name {
+		case "word/document.xml":
+			return FormatDOCX, nil
+		case "mimetype":
+			mimetype, err := readZipFile(file)
+			if err != nil {
+				return "", err
+			}
+			switch strings.TrimSpace(string(mimetype)) {
+			case odtMimeType:
+				return FormatODT, nil
+			case epubMimeType:
+				return FormatEPUB, nil
+			}
+		}
+	}
+
+	return "", errUnknownArchive
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	r, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,126 @@
+package flesch_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// zipArchive builds an archive of the given files, in order.
+func zipArchive(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file[0])
+		if err != nil {
+			t.Fatalf("creating %s: %s", file[0], err)
+		}
+		if _, err := w.Write([]byte(file[1])); err != nil {
+			t.Fatalf("writing %s: %s", file[0], err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("closing archive: %s", err)
+	}
+
+	return buf.Bytes()
+}
+
+const docxDocument = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+  xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>A Heading</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">The cat </w:t></w:r><w:del><w:r><w:delText>never </w:delText></w:r></w:del><w:r><w:t>sat</w:t></w:r><w:commentRangeStart w:id="0"/><w:r><w:t xml:space="preserve"> on the</w:t></w:r><w:r><w:tab/><w:t>mat.</w:t></w:r><w:r><w:commentReference w:id="0"/></w:r></w:p>
+<w:p></w:p>
+<w:p><w:moveFrom><w:r><w:t>Moved away.</w:t></w:r></w:moveFrom><w:r><w:t>It was happy.</w:t></w:r></w:p>
+<w:p><mc:AlternateContent><mc:Choice><w:r><w:t>Box text.</w:t></w:r></mc:Choice><mc:Fallback><w:r><w:t>Box text.</w:t></w:r></mc:Fallback></mc:AlternateContent></w:p>
+</w:body>
+</w:document>`
+
+func docxFile(t *testing.T) []byte {
+	return zipArchive(t,
+		[2]string{"[Content_Types].xml", `<Types/>`},
+		[2]string{"word/document.xml", docxDocument},
+		[2]string{"word/header1.xml", `<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:p><w:r><w:t>Header text.</w:t></w:r></w:p></w:hdr>`},
+		[2]string{"word/comments.xml", `<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:comment><w:p><w:r><w:t>A comment.</w:t></w:r></w:p></w:comment></w:comments>`},
+	)
+}
+
+const odtContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:text>
+<text:tracked-changes><text:changed-region text:id="c1"><text:deletion><text:p>Deleted text.</text:p></text:deletion></text:changed-region></text:tracked-changes>
+<text:h text:outline-level="1">A Heading</text:h>
+<text:p>The cat<text:s/>sat<office:annotation><text:p>A comment.</text:p></office:annotation> on the<text:tab/>mat.<text:note><text:note-body><text:p>A footnote.</text:p></text:note-body></text:note></text:p>
+<text:list><text:list-item><text:p>It was <text:span>happy</text:span>.</text:p></text:list-item></text:list>
+</office:text></office:body>
+</office:document-content>`
+
+func odtFile(t *testing.T) []byte {
+	return zipArchive(t,
+		[2]string{"mimetype", "application/vnd.oasis.opendocument.text"},
+		[2]string{"content.xml", odtContent},
+		[2]string{"styles.xml", `<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><text:p>Footer text.</text:p></office:document-styles>`},
+	)
+}
+
+func TestOfficeDocuments(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Data     []byte
+		Expected []string
+	}{
+		{"docx", docxFile(t), []string{"A Heading", "The cat sat on the mat.", "It was happy.", "Box text."}},
+		{"odt", odtFile(t), []string{"A Heading", "The cat sat on the mat.", "It was happy."}},
+	}
+	for _, test := range testCases {
+		// the format is recognized from the contents
+		sentences := sentenceStrings(t, string(test.Data))
+		if strings.Join(sentences, "|") != strings.Join(test.Expected, "|") {
+			t.Errorf("%s: expected %q, got %q", test.Name, test.Expected, sentences)
+		}
+	}
+}
+
+func TestOfficeDocumentPositions(t *testing.T) {
+	document, err := flesch.ParseString(string(docxFile(t)), "words.docx")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	happy := document.Sentences[2].Words[2]
+	if happy.String() != "happy" || happy.StartPos.Line != 3 {
+		t.Errorf("expected happy in paragraph 3, got %q in %d", happy, happy.StartPos.Line)
+	}
+}
+
+func TestParseFileDetectsOfficeDocuments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flesch")
+	if err != nil {
+		t.Fatalf("creating a directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "story.odt")
+	if err := ioutil.WriteFile(filename, odtFile(t), 0644); err != nil {
+		t.Fatalf("writing %s: %s", filename, err)
+	}
+	document, err := flesch.ParseFile(filename)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if document.WordCount() != 11 {
+		t.Errorf("expected 11 words, got %d", document.WordCount())
+	}
+
+	_, err = flesch.ParseString(string(zipArchive(t, [2]string{"other.txt", "Not a document."})), "other")
+	if err == nil {
+		t.Error("expected an error for an unknown archive")
+	}
+}
@@ -0,0 +1,160 @@
+package flesch
+
+// Option configures how text is parsed.
+type Option func(*config)
+
+type config struct {
+	counter        SyllableCounter
+	language       *Language
+	titles         []string
+	abbreviations  []string
+	semicolons     bool
+	splitCompounds bool
+	format         Format
+	htmlRegion     string
+	gutenberg      bool
+	headings       bool
+}
+
+func newConfig(options []Option) config {
+	c := config{semicolons: true}
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// WithSyllableCounter makes every parsed word count its syllables
+// with counter instead of the language's counter.
+func WithSyllableCounter(counter SyllableCounter) Option {
+	return func(c *config) {
+		c.counter = counter
+	}
+}
+
+// WithLanguage tokenizes and scores the text as the given language,
+// English being the default.
+func WithLanguage(language *Language) Option {
+	return func(c *config) {
+		c.language = language
+	}
+}
+
+// WithAbbreviations adds to the abbreviations whose period does not end
+// a sentence unless the next word is capitalized, such as "etc." or "Jan."
+func WithAbbreviations(abbreviations ...string) Option {
+	return func(c *config) {
+		c.abbreviations = append(c.abbreviations, abbreviations...)
+	}
+}
+
+// WithTitles adds to the abbreviations whose period never ends a
+// sentence, such as "Mr." or "Dr."
+func WithTitles(titles ...string) Option {
+	return func(c *config) {
+		c.titles = append(c.titles, titles...)
+	}
+}
+
+// WithSemicolonStops sets whether a semicolon ends a sentence, which it
+// does by default.
+func WithSemicolonStops(stops bool) Option {
+	return func(c *config) {
+		c.semicolons = stops
+	}
+}
+
+// WithSplitCompounds sets whether each part of a hyphenated compound
+// like "state-of-the-art" is counted as a word of its own. By default
+// the compound is a single word.
+func WithSplitCompounds(split bool) Option {
+	return func(c *config) {
+		c.splitCompounds = split
+	}
+}
+
+// WithFormat reads the input as the given format instead of plain text.
+// ParseFile detects the format of a file from its name unless one is
+// given.
+func WithFormat(format Format) Option {
+	return func(c *config) {
+		c.format = format
+	}
+}
+
+// WithHTMLRegion only scores the parts of an HTML page matched by a CSS
+// selector, such as "main" or "article". Type, id and class selectors
+// and descendant combinators are supported, as in "#content .body".
+func WithHTMLRegion(selector string) Option {
+	return func(c *config) {
+		c.htmlRegion = selector
+	}
+}
+
+// WithGutenbergStripping leaves the header and license of a Project
+// Gutenberg ebook in plain text out of scoring: everything up to its
+// "*** START OF" marker and the credits after it, and everything from
+// its "*** END OF" marker on. Parse reports what was left out in the
+// document's Stripped ranges.
+func WithGutenbergStripping() Option {
+	return func(c *config) {
+		c.gutenberg = true
+	}
+}
+
+// WithHeadingStripping leaves headings and tables of contents in plain
+// text out of scoring: lines standing alone that are all capitals or
+// start like "CHAPTER 12" or "Book IV", and runs of such lines or of
+// lines ending in page numbers. Headings rarely end in a sentence stop,
+// so otherwise they are scored as part of the sentence that follows.
+func WithHeadingStripping() Option {
+	return func(c *config) {
+		c.headings = true
+	}
+}
+
+func (c config) lang() *Language {
+	if c.language == nil {
+		return English
+	}
+
+	return c.language
+}
+
+// syllableCounter is the counter for parsed words. It is nil for the
+// default of English text, which words know how to count themselves.
+func (c config) syllableCounter() SyllableCounter {
+	if c.counter != nil {
+		return c.counter
+	}
+	if c.language != nil && c.language != English {
+		return c.language.Counter
+	}
+
+	return nil
+}
+
+func (c config) segmenter() segmenter {
+	seg := segmenter{
+		typeOf:        c.lang().TypeOfRune,
+		titles:        defaultTitles,
+		abbreviations: defaultAbbreviations,
+		semicolons:    c.semicolons,
+	}
+	if len(c.titles) > 0 {
+		seg.titles = addWords(wordSet(titles), c.titles)
+	}
+	if len(c.abbreviations) > 0 {
+		seg.abbreviations = addWords(wordSet(abbreviations), c.abbreviations)
+	}
+
+	return seg
+}
+
+func (c config) tokenizer() tokenizer {
+	return tokenizer{
+		typeOf:         c.lang().TypeOfRune,
+		splitCompounds: c.splitCompounds,
+	}
+}
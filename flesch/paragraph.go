@@ -0,0 +1,200 @@
+package flesch
+
+import "strings"
+
+// Paragraph is a run of sentences of a document. In plain text,
+// paragraphs are separated by blank lines; in other formats, they are
+// its blocks: paragraphs, headings, list items and table cells.
+type Paragraph struct {
+	Sentences []Sentence
+	// Heading is set on a heading of the document: one its format marks
+	// as such, or in plain text, a line that is all capitals or a few
+	// starting like "CHAPTER 12", without a sentence stop, or a line of
+	// nothing but a chapter's number, like "CHAPTER 12."
+	Heading  bool
+	language *Language
+}
+
+// Section is a heading of a document and the paragraphs up to the next
+// one. The paragraphs before the first heading are a section without
+// one.
+type Section struct {
+	// Paragraphs start with the section's heading, if it has one
+	Paragraphs []Paragraph
+	language   *Language
+}
+
+// Paragraphs splits the document into its paragraphs. A document not
+// made by parsing is a single paragraph.
+func (d Document) Paragraphs() []Paragraph {
+	var paragraphs []Paragraph
+	for i, sentence := range d.Sentences {
+		if i == 0 || sentence.paragraphStart {
+			paragraphs = append(paragraphs, Paragraph{language: d.language})
+		}
+		last := &paragraphs[len(paragraphs)-1]
+		last.Sentences = append(last.Sentences, sentence)
+	}
+	for i := range paragraphs {
+		paragraphs[i].Heading = paragraphs[i].isHeading()
+	}
+
+	return paragraphs
+}
+
+// Sections splits the document into sections at its headings.
+func (d Document) Sections() []Section {
+	var sections []Section
+	for i, paragraph := range d.Paragraphs() {
+		if i == 0 || paragraph.Heading {
+			sections = append(sections, Section{language: d.language})
+		}
+		last := &sections[len(sections)-1]
+		last.Paragraphs = append(last.Paragraphs, paragraph)
+	}
+
+	return sections
+}
+
+// HardestParagraph finds the paragraph with the lowest reading ease of
+// those with at least minWords words, leaving out headings. It reports
+// false if there is none.
+func (d Document) HardestParagraph(minWords int) (Paragraph, bool) {
+	var hardest Paragraph
+	var found bool
+	for _, paragraph := range d.Paragraphs() {
+		if paragraph.Heading || paragraph.WordCount() < minWords {
+			continue
+		}
+		if !found || paragraph.Score() < hardest.Score() {
+			hardest, found = paragraph, true
+		}
+	}
+
+	return hardest, found
+}
+
+func (p Paragraph) isHeading() bool {
+	first, last := p.Sentences[0], p.Sentences[len(p.Sentences)-1]
+	if first.heading {
+		return true
+	}
+	// a heading of plain text is wrapped over a few lines at most, and
+	// one in capitals is a single line. Unlike prose, it has no sentence
+	// stop, unless it is only a chapter's number, as in "CHAPTER II."
+	lines := last.EndPos.Line - first.StartPos.Line + 1
+	text := p.String()
+	if lines > maxHeadingLines {
+		return false
+	}
+	if chapterLine.MatchString(text) {
+		return true
+	}
+	if strings.IndexFunc(text, isSentenceStop) >= 0 {
+		return false
+	}
+
+	return lines == 1 && isCapitalized(text) || chapterHeading.MatchString(text)
+}
+
+func isSentenceStop(r rune) bool {
+	return typeOfNonLetter(r) == RuneTypeSentenceStop || r == '…'
+}
+
+// String joins the sentences of the paragraph with spaces.
+func (p Paragraph) String() string {
+	sentences := make([]string, len(p.Sentences))
+	for i, sentence := range p.Sentences {
+		sentences[i] = sentence.String()
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+// StartPos is the position of the start of the paragraph's first
+// sentence.
+func (p Paragraph) StartPos() Position {
+	return p.Sentences[0].StartPos
+}
+
+// EndPos is the position of the end of the paragraph's last sentence.
+func (p Paragraph) EndPos() Position {
+	return p.Sentences[len(p.Sentences)-1].EndPos
+}
+
+func (p Paragraph) WordCount() int {
+	var count int
+	for _, sentence := range p.Sentences {
+		count += len(sentence.Words)
+	}
+
+	return count
+}
+
+// Counts totals the sentences, words and syllables of the paragraph.
+func (p Paragraph) Counts() Counts {
+	var counts Counts
+	for _, s := range p.Sentences {
+		counts.Add(s)
+	}
+
+	return counts
+}
+
+// Score is the reading ease of the paragraph, as for its document.
+func (p Paragraph) Score() float32 {
+	return Document{language: p.language}.Language().Ease(p.Counts())
+}
+
+func (p Paragraph) Kincaid() float32 {
+	return p.Counts().Kincaid()
+}
+
+func (p Paragraph) ReadableScore() string {
+	return readableScore(p.Score())
+}
+
+// Heading returns the heading the section starts with, reporting false
+// for the text before a document's first heading.
+func (s Section) Heading() (Paragraph, bool) {
+	if len(s.Paragraphs) == 0 || !s.Paragraphs[0].Heading {
+		return Paragraph{}, false
+	}
+
+	return s.Paragraphs[0], true
+}
+
+// Title is the text of the section's heading, with its whitespace
+// collapsed, or "" if it has none.
+func (s Section) Title() string {
+	heading, ok := s.Heading()
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(heading.String()), " ")
+}
+
+// Counts totals the sentences, words and syllables of the section,
+// including its heading.
+func (s Section) Counts() Counts {
+	var counts Counts
+	for _, p := range s.Paragraphs {
+		counts.Merge(p.Counts())
+	}
+
+	return counts
+}
+
+// Score is the reading ease of the section, as for its document.
+func (s Section) Score() float32 {
+	return Document{language: s.language}.Language().Ease(s.Counts())
+}
+
+func (s Section) Kincaid() float32 {
+	return s.Counts().Kincaid()
+}
+
+func (s Section) ReadableScore() string {
+	return readableScore(s.Score())
+}
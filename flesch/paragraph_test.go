@@ -0,0 +1,163 @@
+package flesch_test
+
+import (
+	"fmt"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+const paragraphSample = `CHAPTER I
+The Start
+
+The cat sat on the mat. It was happy.
+The dog came by.
+
+Notwithstanding considerable institutional disagreement, the administration
+characterized the unprecedented reorganization as fundamentally inevitable.
+
+CHAPTER II.
+
+The end.
+`
+
+func TestParagraphs(t *testing.T) {
+	document, err := flesch.ParseString(paragraphSample, "sample.txt")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	paragraphs := document.Paragraphs()
+	expected := []string{
+		"CHAPTER I\nThe Start",
+		"The cat sat on the mat.|It was happy.|The dog came by.",
+		"Notwithstanding considerable institutional disagreement, the administration\ncharacterized the unprecedented reorganization as fundamentally inevitable.",
+		"CHAPTER II.",
+		"The end.",
+	}
+	if len(paragraphs) != len(expected) {
+		t.Fatalf("expected %d paragraphs, got %d", len(expected), len(paragraphs))
+	}
+	for i, paragraph := range paragraphs {
+		var sentences []string
+		for _, sentence := range paragraph.Sentences {
+			sentences = append(sentences, sentence.String())
+		}
+		if strings.Join(sentences, "|") != expected[i] {
+			t.Errorf("paragraph %d: expected %q, got %q", i, expected[i], sentences)
+		}
+		if heading := i == 0 || i == 3; paragraph.Heading != heading {
+			t.Errorf("paragraph %d: expected heading %v, got %v", i, heading, paragraph.Heading)
+		}
+	}
+	if paragraphs[1].StartPos().String() != "4:1" || paragraphs[1].EndPos().String() != "5:16" {
+		t.Errorf("expected the second paragraph at 4:1-5:16, got %s-%s", paragraphs[1].StartPos(), paragraphs[1].EndPos())
+	}
+
+	hardest, ok := document.HardestParagraph(5)
+	if !ok || !strings.HasPrefix(hardest.String(), "Notwithstanding") {
+		t.Errorf("expected the third paragraph to be the hardest, got %q", hardest)
+	}
+	if hardest.Score() >= paragraphs[1].Score() || hardest.Kincaid() <= paragraphs[1].Kincaid() {
+		t.Errorf("expected the hardest paragraph to score worse than %.2f, got %.2f", paragraphs[1].Score(), hardest.Score())
+	}
+	if _, ok := document.HardestParagraph(100); ok {
+		t.Error("expected no paragraph of 100 words")
+	}
+
+	var counts flesch.Counts
+	for _, paragraph := range paragraphs {
+		counts.Merge(paragraph.Counts())
+	}
+	if counts != document.Counts() {
+		t.Errorf("expected the paragraphs to add up to %v, got %v", document.Counts(), counts)
+	}
+}
+
+func TestSections(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Text     string
+		Options  []flesch.Option
+		Expected []string
+	}{
+		{"text", paragraphSample, nil, []string{"CHAPTER I The Start", "CHAPTER II."}},
+		{"markdown", "Intro text.\n\n# Getting started\n\nInstall it. Run it.\n\nSetext\n======\n\n- an item\n", []flesch.Option{flesch.WithFormat(flesch.FormatMarkdown)}, []string{"", "Getting started", "Setext"}},
+		{"html", "<h1>Title</h1><p>Some text.</p><h2>More <em>text</em></h2><p>Other text.</p>", []flesch.Option{flesch.WithFormat(flesch.FormatHTML)}, []string{"Title", "More text"}},
+		{"docx", string(docxFile(t)), nil, []string{"A Heading"}},
+		{"odt", string(odtFile(t)), nil, []string{"A Heading"}},
+	}
+	for _, test := range testCases {
+		document, err := flesch.ParseString(test.Text, test.Name, test.Options...)
+		if err != nil {
+			t.Fatalf("%s: parsing: %s", test.Name, err)
+		}
+		var titles []string
+		for _, section := range document.Sections() {
+			titles = append(titles, section.Title())
+		}
+		if strings.Join(titles, "|") != strings.Join(test.Expected, "|") {
+			t.Errorf("%s: expected sections %q, got %q", test.Name, test.Expected, titles)
+		}
+	}
+}
+
+func TestSectionCounts(t *testing.T) {
+	document, err := flesch.ParseString(paragraphSample, "sample.txt")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	sections := document.Sections()
+	if len(sections) != 2 || len(sections[0].Paragraphs) != 3 {
+		t.Fatalf("expected two sections, the first of three paragraphs, got %d", len(sections))
+	}
+	if sections[1].Counts().Words != 4 {
+		t.Errorf("expected 4 words in the last section, got %d", sections[1].Counts().Words)
+	}
+	if heading, ok := sections[1].Heading(); !ok || heading.String() != "CHAPTER II." {
+		t.Errorf("expected the heading CHAPTER II., got %q", heading)
+	}
+	if sections[1].Score() <= sections[0].Score() {
+		t.Errorf("expected the last section to be easier than %.2f, got %.2f", sections[0].Score(), sections[1].Score())
+	}
+}
+
+func TestPlainTextHeadings(t *testing.T) {
+	testCases := []struct {
+		Text       string
+		Paragraphs []string
+		Headings   []bool
+	}{
+		// a blank line ends a paragraph without a sentence stop
+		{"A title\n\nThe text of it.", []string{"A title", "The text of it."}, []bool{false, false}},
+		{"CHAPTER 1\n\nCall me Ishmael. Some years ago I went.", []string{"CHAPTER 1", "Call me Ishmael. Some years ago I went."}, []bool{true, false}},
+		{"Chapter 12:\n\nIt rained.", []string{"Chapter 12:", "It rained."}, []bool{true, false}},
+		{"THE END\n\nIt rained.", []string{"THE END", "It rained."}, []bool{true, false}},
+		// prose starting like a chapter heading, or in capitals, is not one
+		{"Book 2 is better than book 1.", []string{"Book 2 is better than book 1."}, []bool{false}},
+		{"STOP THAT. NOW.", []string{"STOP THAT. NOW."}, []bool{false}},
+	}
+	for _, test := range testCases {
+		document, err := flesch.ParseString(test.Text, "headings.txt")
+		if err != nil {
+			t.Fatalf("%q: parsing: %s", test.Text, err)
+		}
+		var paragraphs []string
+		var headings []bool
+		for _, paragraph := range document.Paragraphs() {
+			paragraphs = append(paragraphs, paragraph.String())
+			headings = append(headings, paragraph.Heading)
+		}
+		if strings.Join(paragraphs, "|") != strings.Join(test.Paragraphs, "|") || fmt.Sprint(headings) != fmt.Sprint(test.Headings) {
+			t.Errorf("%q: expected paragraphs %q with headings %v, got %q with %v",
+				test.Text, test.Paragraphs, test.Headings, paragraphs, headings)
+		}
+	}
+
+	document, err := flesch.ParseString("CHAPTER 1\n\nCall me Ishmael. Some years ago I went.", "moby.txt")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if hardest, ok := document.HardestParagraph(3); !ok || !strings.HasPrefix(hardest.String(), "Call me") {
+		t.Errorf("expected the paragraph after the heading to be the hardest, got %q", hardest)
+	}
+}
@@ -1,81 +1,218 @@
 package flesch
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"strings"
+	"unicode"
 )
 
-func ParseFile(filename string) (Document, error) {
-	rawData, err := ioutil.ReadFile(filename)
+// ParseFile parses a file, detecting its format from its name unless
+// WithFormat is given. A file whose name does not tell is read like
+// any other input.
+func ParseFile(filename string, options ...Option) (Document, error) {
+	if format := DetectFormat(filename); format != "" && newConfig(options).format == "" {
+		options = append([]Option{WithFormat(format)}, options...)
+	}
+	file, err := os.Open(filename)
 	if err != nil {
 		return Document{name: filename}, fmt.Errorf("reading %s: %w", filename, err)
 	}
-	return ParseString(string(rawData), filename)
+	defer file.Close()
+
+	document, err := Parse(file, filename, options...)
+	if err != nil {
+		return document, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	return document, nil
 }
 
-func ParseString(text, name string) (Document, error) {
-	report := Document{name: name}
+func ParseString(text, name string, options ...Option) (Document, error) {
+	return Parse(strings.NewReader(text), name, options...)
+}
 
-	runes := []rune(text)
-	var currentRuneIndex int
-	for {
-		sentence, err := GetSentence(runes, currentRuneIndex)
+// Parse reads all of r and collects its sentences into a Document.
+// Unlike ParseStream, it holds sentences of any length.
+func Parse(r io.Reader, name string, options ...Option) (Document, error) {
+	report := Document{name: name, language: newConfig(options).lang()}
+	var err error
+	report.Stripped, err = parseStream(r, func(sentence Sentence) error {
+		report.Sentences = append(report.Sentences, sentence)
+		return nil
+	}, options, 0)
+
+	return report, err
+}
+
+// ParseStream tokenizes r incrementally, calling fn with each sentence
+// as soon as it is complete. Only the sentence being read is buffered,
+// so combined with Counts it can score input of any size. Parsing stops
+// at the first error returned by fn, or at a sentence longer than
+// DefaultMaxSentenceLength with ErrSentenceTooLong.
+//
+// Input in a format other than plain text is read whole first, to
+// extract its prose. An EPUB book is read a chapter at a time. Unless
+// WithFormat is given, DOCX, ODT and EPUB files are recognized by their
+// contents, and anything else is plain text. So is plain text to be
+// stripped of its Gutenberg header or its headings.
+func ParseStream(r io.Reader, fn func(Sentence) error, options ...Option) error {
+	_, err := parseStream(r, fn, options, DefaultMaxSentenceLength)
+	return err
+}
+
+// parseStream is ParseStream, returning the ranges of plain text left
+// out of scoring, with the longest sentence max runes, or any if max is 0.
+func parseStream(r io.Reader, fn func(Sentence) error, options []Option, max int) ([]StrippedRange, error) {
+	c := newConfig(options)
+	format, archive := c.format, false
+	if format == "" {
+		buffered := bufio.NewReader(r)
+		r = buffered
+		head, _ := buffered.Peek(len(zipMagic))
+		archive = bytes.Equal(head, zipMagic)
+		format = FormatText
+	}
+	relocate := func(s Sentence) Sentence { return s }
+	var stripped []StrippedRange
+	if format != FormatText || archive || c.gutenberg || c.headings {
+		source, err := ioutil.ReadAll(r)
 		if err != nil {
-			break
+			return nil, err
 		}
-		// get words in sentence
-		for {
-			word, err := GetWord(runes, currentRuneIndex, sentence.End)
-			if err != nil {
-				break
+		if archive {
+			if format, err = archiveFormat(source); err != nil {
+				return nil, err
 			}
-			sentence.Words = append(sentence.Words, word)
-			currentRuneIndex = word.End + 1
 		}
-		report.Sentences = append(report.Sentences, sentence)
-		currentRuneIndex = sentence.End + 1
+		var prose *prose
+		switch extract, ok := extractors[format]; {
+		case format == FormatEPUB:
+			return nil, streamEPUB(source, fn, options)
+		case format == FormatText:
+			prose, stripped = stripText(string(source), c)
+		case !ok:
+			return nil, fmt.Errorf("unknown format %q", format)
+		default:
+			if prose, err = extract(source, c); err != nil {
+				return nil, fmt.Errorf("%s: %w", format, err)
+			}
+		}
+		r = strings.NewReader(prose.text.String())
+		relocate = prose.relocate
+	}
+	scanner := NewScanner(r, options...)
+	scanner.MaxSentenceLength(max)
+	for scanner.Scan() {
+		if err := fn(relocate(scanner.Sentence())); err != nil {
+			return stripped, err
+		}
 	}
 
-	return report, nil
+	return stripped, scanner.Err()
 }
 
 var NoMoreSentences = errors.New("no more sentences")
 var NoMoreWords = errors.New("no more words")
 
 func GetSentence(allRunes []rune, start int) (Sentence, error) {
-	i := start
-	sentence := Sentence{allRunes: allRunes}
+	if start >= len(allRunes) {
+		return Sentence{}, NoMoreSentences
+	}
+	_, sentence, found := splitSentence(allRunes[start:], true, newConfig(nil).segmenter())
+	if !found {
+		return Sentence{}, NoMoreSentences
+	}
+	sentence.Start += start
+	sentence.End += start
+	sentence.runes = allRunes[sentence.Start : sentence.End+1]
+
+	return sentence, nil
+}
+
+// splitSentence looks for the first sentence in runes, returning the
+// number of runes consumed and whether a sentence was found. Sentence
+// offsets are relative to runes, and the sentence has no words or runes
+// of its own yet. Until atEOF, an unfinished sentence is left unconsumed
+// so that it can be completed by more input. A paragraph separator or a
+// blank line ends a sentence even without a sentence stop.
+func splitSentence(runes []rune, atEOF bool, seg segmenter) (int, Sentence, bool) {
+	var sentence Sentence
 	var sentenceStarted bool
-	for {
-		if i >= len(allRunes) {
-			return sentence, NoMoreSentences
+	var last, newlines int
+	typeOf := seg.typeOf
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			newlines++
+		} else if !unicode.IsSpace(r) {
+			newlines = 0
+		}
+		if sentenceStarted && (r == paragraphSeparator || newlines == 2) {
+			sentence.End = last
+			return i + 1, sentence, true
 		}
-		r := allRunes[i]
 		// if the sentence hasn't started yet...
 		if !sentenceStarted {
 			// if the rune is a vowel or consonant, the sentence will have started here
-			if TypeOfRune(r) == RuneTypeVowel || TypeOfRune(r) == RuneTypeConsonant {
+			if typeOf(r) == RuneTypeVowel || typeOf(r) == RuneTypeConsonant {
 				sentenceStarted = true
 				sentence.Start = i
 			}
 		} else {
-			if TypeOfRune(r) == RuneTypeSentenceStop {
-				sentence.End = i
-				return sentence, nil
+			if typeOf(r) == RuneTypeSentenceStop || r == '…' {
+				end, isBoundary, needMore := seg.boundary(runes, i, atEOF)
+				if needMore {
+					break
+				}
+				if isBoundary {
+					sentence.End = end
+					return end + 1, sentence, true
+				}
+				i = end
 			}
 		}
+		if !unicode.IsSpace(runes[i]) {
+			last = i
+		}
+	}
 
-		i++
+	// an unterminated sentence at the end of the input is discarded
+	if !sentenceStarted || atEOF {
+		return len(runes), sentence, false
 	}
+
+	return sentence.Start, sentence, false
 }
 
 func GetWord(allRunes []rune, start int, stop int) (Word, error) {
+	return newConfig(nil).tokenizer().word(allRunes, start, stop)
+}
+
+// tokenizer splits sentences into words.
+type tokenizer struct {
+	typeOf         func(rune) RuneType
+	splitCompounds bool
+}
+
+// word finds the first word in allRunes[start:stop+1].
+func (t tokenizer) word(allRunes []rune, start int, stop int) (Word, error) {
 	i := start
-	word := Word{allRunes: allRunes}
-	var wordStarted bool
+	word := Word{}
+	var wordStarted, url bool
 	for {
 		if i > stop {
+			if wordStarted {
+				word.End = stop
+				word.runes = allRunes[word.Start : stop+1]
+
+				return word, nil
+			}
 
 			return word, NoMoreWords
 		}
@@ -83,21 +220,107 @@ func GetWord(allRunes []rune, start int, stop int) (Word, error) {
 		// if the word hasn't started yet...
 		if !wordStarted {
 			// if the rune is a vowel or consonant, the word will have started here
-			if TypeOfRune(r) == RuneTypeVowel || TypeOfRune(r) == RuneTypeConsonant {
+			if t.typeOf(r) == RuneTypeVowel || t.typeOf(r) == RuneTypeConsonant {
 				wordStarted = true
 				word.Start = i
+				url = hasPrefixFold(allRunes[i:stop+1], "www.")
 			}
-		} else {
-			if TypeOfRune(r) == RuneTypeWhiteSpace ||
-				TypeOfRune(r) == RuneTypeWordStop ||
-				TypeOfRune(r) == RuneTypeSentenceStop {
-
-				word.End = i - 1
+		} else if t.wordEnds(allRunes, i, stop, &url) {
+			word.End = i - 1
+			word.runes = allRunes[word.Start:i]
 
-				return word, nil
-			}
+			return word, nil
 		}
 
 		i++
 	}
 }
+
+// wordEnds decides whether allRunes[i] ends the word before it.
+//
+// Apostrophes between letters are part of a word, as in "don't" and
+// "John's", and so are hyphens between letters, as in "state-of-the-art",
+// unless compounds are split. A slash separates words, as in "and/or",
+// except in a URL. Periods inside "p.m." or "example.com" are part of
+// the word too.
+func (t tokenizer) wordEnds(allRunes []rune, i int, stop int, url *bool) bool {
+	r := allRunes[i]
+	var next rune
+	if i < stop {
+		next = allRunes[i+1]
+	}
+	betweenLetters := unicode.IsLetter(allRunes[i-1]) && unicode.IsLetter(next)
+	switch {
+	case isApostrophe(r):
+		return !betweenLetters
+	case r == '\u00ad':
+		// soft hyphens only show where a word may be broken
+		return false
+	case isHyphen(r):
+		joined := isLetterOrNumber(allRunes[i-1]) && isLetterOrNumber(next)
+		return t.splitCompounds || !joined
+	case r == '/':
+		return !*url
+	case r == ':' && hasPrefixFold(allRunes[i:stop+1], "://"):
+		*url = true
+		return false
+	case isPeriod(r) && isLetterOrNumber(next):
+		return false
+	case r == '…':
+		return true
+	}
+
+	return t.typeOf(r) == RuneTypeWhiteSpace ||
+		t.typeOf(r) == RuneTypeWordStop ||
+		t.typeOf(r) == RuneTypeSentenceStop
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+// straightApostrophes replaces the other apostrophes with a straight
+// one, so that words can be looked up in dictionaries and word lists.
+var straightApostrophes = strings.NewReplacer("’", "'", "ʼ", "'")
+
+func isLetterOrNumber(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func isHyphen(r rune) bool {
+	return r == '-' || r == '‐' || r == '‑'
+}
+
+func hasPrefixFold(runes []rune, prefix string) bool {
+	for _, p := range prefix {
+		if len(runes) == 0 || unicode.ToLower(runes[0]) != p {
+			return false
+		}
+		runes = runes[1:]
+	}
+
+	return true
+}
+
+// wordsOf splits the runes of a sentence into words as configured. The
+// offset of the sentence within the document is added to the offsets
+// of each word.
+func wordsOf(runes []rune, offset int, c config) []Word {
+	var words []Word
+	var currentRuneIndex int
+	tokenizer := c.tokenizer()
+	counter := c.syllableCounter()
+	for {
+		word, err := tokenizer.word(runes, currentRuneIndex, len(runes)-1)
+		if err != nil {
+			break
+		}
+		currentRuneIndex = word.End + 1
+		word.Start += offset
+		word.End += offset
+		word.counter = counter
+		words = append(words, word)
+	}
+
+	return words
+}
@@ -3,8 +3,12 @@ package flesch_test
 import (
 	"fmt"
 	"github.com/PaluMacil/flesch-index/flesch"
+	"io/ioutil"
+	"os"
 	"path"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func TestParseString(t *testing.T) {
@@ -103,3 +107,163 @@ func TestSentences(t *testing.T) {
 		t.Errorf("fifth sentence '', got %s", report.Sentences[4])
 	}
 }
+
+func TestParseMatchesParseString(t *testing.T) {
+	for _, filename := range []string{"GettysburgAddress.txt", "MobyDick.txt", "NYTimes.txt"} {
+		filepath := path.Join("..", filename)
+		rawData, err := ioutil.ReadFile(filepath)
+		if err != nil {
+			t.Fatalf("reading %s: %s", filename, err)
+		}
+		expected, err := flesch.ParseString(string(rawData), filename)
+		if err != nil {
+			t.Fatalf("parsing %s as a string: %s", filename, err)
+		}
+
+		file, err := os.Open(filepath)
+		if err != nil {
+			t.Fatalf("opening %s: %s", filename, err)
+		}
+		var counts flesch.Counts
+		err = flesch.ParseStream(iotest.OneByteReader(file), func(s flesch.Sentence) error {
+			counts.Add(s)
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			t.Fatalf("streaming %s: %s", filename, err)
+		}
+
+		if counts != expected.Counts() {
+			t.Errorf("%s: expected counts %+v, got %+v", filename, expected.Counts(), counts)
+		}
+		if counts.Score() != expected.Score() {
+			t.Errorf("%s: expected score %v, got %v", filename, expected.Score(), counts.Score())
+		}
+		if counts.Kincaid() != expected.Kincaid() {
+			t.Errorf("%s: expected Kincaid %v, got %v", filename, expected.Kincaid(), counts.Kincaid())
+		}
+	}
+}
+
+func TestScannerSentencesAcrossReads(t *testing.T) {
+	// sentences longer than a single read must still come out whole
+	long := strings.Repeat("word ", 2000) + "end. Short one!"
+	scanner := flesch.NewScanner(strings.NewReader(long))
+	var sentences []flesch.Sentence
+	for scanner.Scan() {
+		sentences = append(sentences, scanner.Sentence())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanning: %s", err)
+	}
+	if len(sentences) != 2 {
+		t.Fatalf("expected 2 sentences, got %d", len(sentences))
+	}
+	if len(sentences[0].Words) != 2001 {
+		t.Errorf("expected 2001 words in the first sentence, got %d", len(sentences[0].Words))
+	}
+	if sentences[1].String() != "Short one!" || sentences[1].Start != 10005 {
+		t.Errorf("expected 'Short one!' at 10005, got '%s' at %d", sentences[1], sentences[1].Start)
+	}
+	if sentences[1].Words[1].String() != "one" || sentences[1].Words[1].Start != 10011 {
+		t.Errorf("expected 'one' at 10011, got '%s' at %d", sentences[1].Words[1], sentences[1].Words[1].Start)
+	}
+}
+
+func TestScannerMaxSentenceLength(t *testing.T) {
+	scanner := flesch.NewScanner(strings.NewReader(strings.Repeat("word ", 10000) + "end."))
+	scanner.MaxSentenceLength(1000)
+	for scanner.Scan() {
+	}
+	if scanner.Err() != flesch.ErrSentenceTooLong {
+		t.Errorf("expected %v, got %v", flesch.ErrSentenceTooLong, scanner.Err())
+	}
+}
+
+func TestParseLongSentence(t *testing.T) {
+	text := strings.Repeat("word ", flesch.DefaultMaxSentenceLength/5+1) + "end."
+	document, err := flesch.ParseString(text, "long")
+	if err != nil || len(document.Sentences) != 1 {
+		t.Fatalf("expected a single sentence and no error, got %d and %v", len(document.Sentences), err)
+	}
+	err = flesch.ParseStream(strings.NewReader(text), func(flesch.Sentence) error { return nil })
+	if err != flesch.ErrSentenceTooLong {
+		t.Errorf("expected %v streaming, got %v", flesch.ErrSentenceTooLong, err)
+	}
+}
+
+func TestGetSentencePastEnd(t *testing.T) {
+	runes := []rune("The end.")
+	for _, start := range []int{len(runes), len(runes) + 5} {
+		if _, err := flesch.GetSentence(runes, start); err != flesch.NoMoreSentences {
+			t.Errorf("start %d: expected %v, got %v", start, flesch.NoMoreSentences, err)
+		}
+	}
+}
+
+func TestUnicodeWords(t *testing.T) {
+	report, err := flesch.ParseString("The naïve café served crème brûlée. Привет, мир!", "unicode")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if len(report.Sentences) != 2 {
+		t.Fatalf("expected 2 sentences, got %d", len(report.Sentences))
+	}
+	var words []string
+	for _, w := range report.Words() {
+		words = append(words, w.String())
+	}
+	if strings.Join(words, " ") != "The naïve café served crème brûlée Привет мир" {
+		t.Errorf("unexpected words %q", words)
+	}
+}
+
+func wordStrings(t *testing.T, text string, options ...flesch.Option) []string {
+	t.Helper()
+	document, err := flesch.ParseString(text, "words", options...)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var words []string
+	for _, w := range document.Words() {
+		words = append(words, w.String())
+	}
+
+	return words
+}
+
+func TestWordTokenization(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Expected string
+	}{
+		{"I don't know; you don’t either.", "I|don't|know|you|don’t|either"},
+		{"It is John’s and the dogs’ dinner.", "It|is|John’s|and|the|dogs|dinner"},
+		{"‘Stop,’ she said.", "Stop|she|said"},
+		{"A state-of-the-art, free-and-easy plan.", "A|state-of-the-art|free-and-easy|plan"},
+		{"Pre- and post-war years--all of them.", "Pre|and|post-war|years|all|of|them"},
+		{"Take one and/or the other.", "Take|one|and|or|the|other"},
+		{"Visit https://example.com/a/b or www.example.com/c today.", "Visit|https://example.com/a/b|or|www.example.com/c|today"},
+		{"Wait…", "Wait"},
+	}
+	for _, test := range testCases {
+		words := strings.Join(wordStrings(t, test.Text), "|")
+		if words != test.Expected {
+			t.Errorf("%s: expected %s, got %s", test.Text, test.Expected, words)
+		}
+	}
+
+	words := strings.Join(wordStrings(t, "A state-of-the-art plan.", flesch.WithSplitCompounds(true)), "|")
+	if words != "A|state|of|the|art|plan" {
+		t.Errorf("expected compounds to be split, got %s", words)
+	}
+}
+
+func TestPossessiveFamiliarWords(t *testing.T) {
+	for _, word := range []string{"mother's", "mother’s", "don’t"} {
+		if !flesch.DaleChallWords().Contains(word) {
+			t.Errorf("expected %s to be familiar", word)
+		}
+	}
+}
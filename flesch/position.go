@@ -0,0 +1,64 @@
+package flesch
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Position locates a rune within a document so that editors and reports
+// can point at it. Line and Column are 1-based, and Column counts runes
+// from the start of the line. Offset is the 0-based byte offset.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position was computed while parsing.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+var startOfDocument = Position{Line: 1, Column: 1}
+
+// advance moves the position past runes, whose encoded lengths in bytes
+// are widths.
+func (p Position) advance(runes []rune, widths []byte) Position {
+	for i, r := range runes {
+		p = p.next(r, int(widths[i]))
+	}
+
+	return p
+}
+
+// advanceString moves the position past text. Like a Scanner, it takes
+// each byte of invalid UTF-8 for a rune.
+func (p Position) advanceString(text string) Position {
+	for len(text) > 0 {
+		r, width := utf8.DecodeRuneInString(text)
+		p = p.next(r, width)
+		text = text[width:]
+	}
+
+	return p
+}
+
+func (p Position) next(r rune, width int) Position {
+	p.Offset += width
+	if r == '\n' {
+		p.Line++
+		p.Column = 1
+	} else {
+		p.Column++
+	}
+
+	return p
+}
@@ -0,0 +1,82 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPositions(t *testing.T) {
+	text := "First line.\n  Über café, naïve?\r\nLast one."
+	document, err := flesch.ParseString(text, "positions")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	if len(document.Sentences) != 3 {
+		t.Fatalf("expected 3 sentences, got %d", len(document.Sentences))
+	}
+
+	cases := []struct {
+		name     string
+		actual   flesch.Position
+		expected flesch.Position
+	}{
+		{"first sentence", document.Sentences[0].StartPos, flesch.Position{Offset: 0, Line: 1, Column: 1}},
+		{"first sentence end", document.Sentences[0].EndPos, flesch.Position{Offset: 10, Line: 1, Column: 11}},
+		{"Über", document.Sentences[1].Words[0].StartPos, flesch.Position{Offset: 14, Line: 2, Column: 3}},
+		{"café", document.Sentences[1].Words[1].StartPos, flesch.Position{Offset: 20, Line: 2, Column: 8}},
+		{"café end", document.Sentences[1].Words[1].EndPos, flesch.Position{Offset: 23, Line: 2, Column: 11}},
+		{"naïve", document.Sentences[1].Words[2].StartPos, flesch.Position{Offset: 27, Line: 2, Column: 14}},
+		{"Last", document.Sentences[2].StartPos, flesch.Position{Offset: 36, Line: 3, Column: 1}},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("%s: expected %s at byte %d, got %s at byte %d", c.name, c.expected, c.expected.Offset, c.actual, c.actual.Offset)
+		}
+	}
+}
+
+func TestPositionsOfInvalidUTF8(t *testing.T) {
+	// each invalid byte is one rune, but only one byte
+	document, err := flesch.ParseString("Bad \xff\xfe bytes. Next one.", "invalid")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	next := document.Sentences[1].StartPos
+	if expected := (flesch.Position{Offset: 14, Line: 1, Column: 15}); next != expected {
+		t.Errorf("expected %s at byte %d, got %s at byte %d", expected, expected.Offset, next, next.Offset)
+	}
+}
+
+func TestPositionsMatchText(t *testing.T) {
+	for _, filename := range []string{"GettysburgAddress.txt", "NYTimes.txt"} {
+		rawData, err := ioutil.ReadFile(path.Join("..", filename))
+		if err != nil {
+			t.Fatalf("reading %s: %s", filename, err)
+		}
+		text := string(rawData)
+		lines := strings.Split(text, "\n")
+		document, err := flesch.ParseString(text, filename)
+		if err != nil {
+			t.Fatalf("parsing %s: %s", filename, err)
+		}
+		for _, w := range document.Words() {
+			atOffset := text[w.StartPos.Offset:]
+			if !strings.HasPrefix(atOffset, w.String()) {
+				t.Fatalf("%s: expected %q at byte %d, found %q", filename, w, w.StartPos.Offset, atOffset[:len(w.String())])
+			}
+			line := []rune(lines[w.StartPos.Line-1])
+			atColumn := string(line[w.StartPos.Column-1:])
+			if !strings.HasPrefix(atColumn, w.String()) {
+				t.Fatalf("%s: expected %q at %s, found %q", filename, w, w.StartPos, atColumn)
+			}
+			lastRune, _ := utf8.DecodeLastRuneInString(w.String())
+			if r, _ := utf8.DecodeRuneInString(text[w.EndPos.Offset:]); r != lastRune {
+				t.Fatalf("%s: expected %q to end with %q at byte %d, found %q", filename, w, lastRune, w.EndPos.Offset, r)
+			}
+		}
+	}
+}
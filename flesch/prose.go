@@ -0,0 +1,165 @@
+package flesch
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// paragraphSeparator ends a sentence wherever it appears, whether or not
+// a sentence stop came first. Front-ends put it after headings, list
+// items, table cells and other blocks of a document.
+const paragraphSeparator = '\u2029'
+
+// prose is the text a front-end extracts from a marked-up document, with
+// a map from the extracted text back to positions in the source.
+type prose struct {
+	source     string
+	lineStarts []int
+	text       strings.Builder
+	spans      []span
+	// headings are the byte ranges of the extracted text that are
+	// headings, in order
+	headings [][2]int
+	// last is the position last found
+	last Position
+}
+
+// span is a run of the extracted text that starts at source in the
+// source document. Within a span, the extracted text is a verbatim copy
+// of the source.
+type span struct {
+	offset int
+	source Position
+}
+
+func newProse(source string) *prose {
+	p := &prose{source: source, lineStarts: []int{0}}
+	for i := 0; i < len(source); i++ {
+		if source[i] == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+
+	return p
+}
+
+// lines returns the start of each line of the source and its end,
+// excluding the line break.
+func (p *prose) lines() [][2]int {
+	var lines [][2]int
+	src := p.source
+	for start := 0; start < len(src); {
+		end := strings.IndexByte(src[start:], '\n')
+		next := start + end + 1
+		if end < 0 {
+			end = len(src) - start
+			next = len(src)
+		}
+		lines = append(lines, [2]int{start, start + len(strings.TrimSuffix(src[start:start+end], "\r"))})
+		start = next
+	}
+
+	return lines
+}
+
+// position finds the line and column of a byte offset of the source.
+// Offsets mostly come in order, so the column is counted on from the
+// last position found on the same line rather than from its start,
+// which on a long line, like that of minified HTML, would take
+// quadratic time.
+func (p *prose) position(offset int) Position {
+	line := sort.Search(len(p.lineStarts), func(i int) bool {
+		return p.lineStarts[i] > offset
+	})
+	from, column := p.lineStarts[line-1], 1
+	if last := p.last; last.Line == line && last.Offset <= offset {
+		from, column = last.Offset, last.Column
+	}
+	p.last = Position{
+		Offset: offset,
+		Line:   line,
+		Column: column + utf8.RuneCountInString(p.source[from:offset]),
+	}
+
+	return p.last
+}
+
+// copy appends source[start:end] to the extracted text.
+func (p *prose) copy(start, end int) {
+	if start >= end {
+		return
+	}
+	p.spans = append(p.spans, span{offset: p.text.Len(), source: p.position(start)})
+	p.text.WriteString(p.source[start:end])
+}
+
+// insert appends text that stands for the source at offset, such as a
+// decoded entity.
+func (p *prose) insert(text string, offset int) {
+	p.spans = append(p.spans, span{offset: p.text.Len(), source: p.position(offset)})
+	p.text.WriteString(text)
+}
+
+// endBlock ends the sentence being extracted, if any, at offset of the
+// source.
+func (p *prose) endBlock(offset int) {
+	if text := p.text.String(); text == "" || strings.HasSuffix(text, string(paragraphSeparator)) {
+		return
+	}
+	p.insert(string(paragraphSeparator), offset)
+}
+
+// markHeading marks the text extracted since start as a heading.
+func (p *prose) markHeading(start int) {
+	if start < p.text.Len() {
+		p.headings = append(p.headings, [2]int{start, p.text.Len()})
+	}
+}
+
+// writeParagraph appends a paragraph of a document without a plain text
+// source, ending it with a line break so that each paragraph is a line.
+func (p *prose) writeParagraph(text string, heading bool) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	if heading {
+		defer p.markHeading(p.text.Len())
+	}
+	p.text.WriteString(text)
+	p.text.WriteString(string(paragraphSeparator) + "\n")
+}
+
+// locate maps a position in the extracted text back to the source.
+// Without a source, it is a position in the extracted text.
+func (p *prose) locate(pos Position) Position {
+	if !pos.IsValid() || len(p.spans) == 0 {
+		return pos
+	}
+	i := sort.Search(len(p.spans), func(i int) bool {
+		return p.spans[i].offset > pos.Offset
+	}) - 1
+	if i < 0 {
+		return pos
+	}
+	s := p.spans[i]
+
+	return s.source.advanceString(p.text.String()[s.offset:pos.Offset])
+}
+
+// relocate maps the positions of a sentence parsed from the extracted
+// text, and those of its words, back to the source, and tells whether
+// the sentence is part of a heading.
+func (p *prose) relocate(sentence Sentence) Sentence {
+	offset := sentence.StartPos.Offset
+	i := sort.Search(len(p.headings), func(i int) bool { return p.headings[i][1] > offset })
+	sentence.heading = i < len(p.headings) && p.headings[i][0] <= offset
+	sentence.StartPos = p.locate(sentence.StartPos)
+	sentence.EndPos = p.locate(sentence.EndPos)
+	for i := range sentence.Words {
+		sentence.Words[i].StartPos = p.locate(sentence.Words[i].StartPos)
+		sentence.Words[i].EndPos = p.locate(sentence.Words[i].EndPos)
+	}
+
+	return sentence
+}
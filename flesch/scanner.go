@@ -0,0 +1,180 @@
+package flesch
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"unicode"
+)
+
+const (
+	// DefaultMaxSentenceLength is the longest sentence, in runes, that a
+	// Scanner will buffer unless told otherwise.
+	DefaultMaxSentenceLength = 1 << 18
+
+	readChunk = 4096
+)
+
+var ErrSentenceTooLong = errors.New("sentence too long")
+
+// Scanner reads sentences one at a time from a stream of text. Only the
+// runes of the sentence being read are held in memory, and each Sentence
+// it returns owns its runes, so earlier sentences may be discarded freely.
+type Scanner struct {
+	reader *bufio.Reader
+	buf    []rune
+	// widths holds the encoded length of each rune of buf, which differs
+	// from utf8.RuneLen for invalid input read as utf8.RuneError.
+	widths []byte
+	offset int
+	pos    Position
+	// newlines counts the line breaks since the last rune that was not
+	// whitespace, and paragraphBreak is set once the text between two
+	// sentences has a blank line or a paragraph separator
+	newlines       int
+	paragraphBreak bool
+	max            int
+	eof            bool
+	sentence       Sentence
+	err            error
+	config         config
+	segmenter      segmenter
+}
+
+func NewScanner(r io.Reader, options ...Option) *Scanner {
+	config := newConfig(options)
+
+	return &Scanner{
+		reader:    bufio.NewReader(r),
+		max:       DefaultMaxSentenceLength,
+		pos:       startOfDocument,
+		config:    config,
+		segmenter: config.segmenter(),
+		// the first sentence starts a paragraph
+		paragraphBreak: true,
+	}
+}
+
+// MaxSentenceLength sets the longest sentence, in runes, the scanner will
+// buffer. Scan fails with ErrSentenceTooLong on a longer one. A max of 0
+// or less sets no limit. It must be called before scanning begins.
+func (s *Scanner) MaxSentenceLength(max int) {
+	s.max = max
+}
+
+// Scan advances to the next sentence, which is then available through
+// Sentence. It returns false at the end of the input or on an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for {
+		advance, sentence, found := splitSentence(s.buf, s.eof, s.segmenter)
+		if found {
+			s.skip(s.buf[:sentence.Start])
+			sentence.paragraphStart = s.paragraphBreak
+			s.paragraphBreak, s.newlines = false, 0
+			s.skip(s.buf[sentence.End+1 : advance])
+			runes := make([]rune, sentence.End-sentence.Start+1)
+			copy(runes, s.buf[sentence.Start:sentence.End+1])
+			sentence.runes = runes
+			sentence.Start += s.offset
+			sentence.End += s.offset
+			sentence.Words = wordsOf(runes, sentence.Start, s.config)
+			sentence.language = s.config.language
+			s.locate(&sentence)
+			s.sentence = sentence
+			s.consume(advance)
+
+			return true
+		}
+		s.skip(s.buf[:advance])
+		s.consume(advance)
+		if s.eof {
+			return false
+		}
+		if s.max > 0 && len(s.buf) >= s.max {
+			s.err = ErrSentenceTooLong
+			return false
+		}
+		if err := s.fill(); err != nil {
+			s.err = err
+			return false
+		}
+	}
+}
+
+// Sentence returns the sentence found by the last call to Scan.
+func (s *Scanner) Sentence() Sentence {
+	return s.sentence
+}
+
+// Err returns the first error encountered while reading, if any.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// locate sets the positions of a sentence and its words, walking the
+// buffer from its start once.
+func (s *Scanner) locate(sentence *Sentence) {
+	pos := s.pos
+	at := 0
+	moveTo := func(offset int) Position {
+		i := offset - s.offset
+		pos = pos.advance(s.buf[at:i], s.widths[at:i])
+		at = i
+
+		return pos
+	}
+	sentence.StartPos = moveTo(sentence.Start)
+	for i := range sentence.Words {
+		word := &sentence.Words[i]
+		word.StartPos = moveTo(word.Start)
+		word.EndPos = moveTo(word.End)
+	}
+	sentence.EndPos = moveTo(sentence.End)
+}
+
+// skip looks for paragraph breaks in runes between sentences.
+func (s *Scanner) skip(runes []rune) {
+	for _, r := range runes {
+		switch {
+		case r == '\n':
+			s.newlines++
+		case r == paragraphSeparator:
+			s.newlines = 2
+		case !unicode.IsSpace(r):
+			s.newlines = 0
+		}
+		if s.newlines >= 2 {
+			s.paragraphBreak = true
+		}
+	}
+}
+
+func (s *Scanner) consume(n int) {
+	s.pos = s.pos.advance(s.buf[:n], s.widths[:n])
+	s.buf = s.buf[n:]
+	s.widths = s.widths[n:]
+	s.offset += n
+}
+
+// fill reads the next chunk of input into the buffer.
+// Consumed runes are never copied; once the capacity left after them
+// runs out, append moves only the unconsumed ones to a new array.
+func (s *Scanner) fill() error {
+	for i := 0; i < readChunk; i++ {
+		r, width, err := s.reader.ReadRune()
+		if err == io.EOF {
+			s.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.buf = append(s.buf, r)
+		s.widths = append(s.widths, byte(width))
+	}
+
+	return nil
+}
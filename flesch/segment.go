@@ -0,0 +1,142 @@
+package flesch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// titles are abbreviations that precede a name, so a period after them
+// never ends a sentence.
+var titles = []string{
+	"adm", "capt", "cmdr", "col", "dr", "fr", "gen", "gov", "hon", "lt",
+	"messrs", "mlle", "mme", "mr", "mrs", "ms", "pres", "prof", "rep",
+	"rev", "sen", "sgt",
+}
+
+// abbreviations often end with a period inside a sentence. A period
+// after them only ends a sentence when the next word is capitalized.
+var abbreviations = []string{
+	"a.d", "a.m", "al", "approx", "apr", "assn", "aug", "ave", "b.a", "b.c",
+	"blvd", "bros", "cf", "ch", "cm", "co", "corp", "d.c", "dec", "dept",
+	"e.g", "ed", "eds", "est", "etc", "feb", "fig", "figs", "fri", "ft",
+	"hr", "hrs", "i.e", "inc", "jan", "jr", "jul", "jun", "kg", "km", "lb",
+	"lbs", "ltd", "m.a", "m.d", "mar", "max", "mi", "min", "mm", "mon", "mt",
+	"no", "nos", "nov", "oct", "oz", "p", "p.m", "ph.d", "pp", "rd", "sat",
+	"sec", "sep", "sept", "sq", "sr", "st", "sun", "thu", "thur", "thurs",
+	"tue", "tues", "u.k", "u.n", "u.s", "univ", "viz", "vol", "vols", "vs",
+	"wed",
+}
+
+var (
+	defaultTitles        = wordSet(titles)
+	defaultAbbreviations = wordSet(abbreviations)
+)
+
+func wordSet(words []string) map[string]bool {
+	return addWords(make(map[string]bool, len(words)), words)
+}
+
+// addWords adds words to a set, without any final period.
+func addWords(set map[string]bool, words []string) map[string]bool {
+	for _, word := range words {
+		set[strings.ToLower(strings.TrimSuffix(word, "."))] = true
+	}
+
+	return set
+}
+
+// segmenter decides where sentences end. Besides the sentence stops of
+// a language, it knows that periods in decimals, URLs, e-mail addresses,
+// titles, initials and abbreviations do not end a sentence, and that an
+// ellipsis only does when a capitalized word follows.
+type segmenter struct {
+	typeOf        func(rune) RuneType
+	titles        map[string]bool
+	abbreviations map[string]bool
+	semicolons    bool
+}
+
+// boundary decides whether the sentence stop at runes[i] ends a sentence.
+// It returns the index of the last rune of the sentence, which is past i
+// for an ellipsis, and whether more input is needed to decide.
+func (seg segmenter) boundary(runes []rune, i int, atEOF bool) (end int, isBoundary bool, needMore bool) {
+	r := runes[i]
+	if r == ';' {
+		return i, seg.semicolons, false
+	}
+	if !isPeriod(r) && r != '…' {
+		return i, true, false
+	}
+
+	// an ellipsis is a run of periods, or the ellipsis rune itself
+	end = i
+	for isPeriod(r) && end+1 < len(runes) && isPeriod(runes[end+1]) {
+		end++
+	}
+	next, found := nextWordStart(runes, end+1)
+	if !found && !atEOF {
+		return end, false, true
+	}
+	capitalized := !found || unicode.IsUpper(runes[next])
+	if r == '…' || end > i {
+		return end, capitalized, false
+	}
+
+	// decimals, URLs and e-mail addresses have no space after a period
+	if end+1 < len(runes) && (unicode.IsLetter(runes[end+1]) || unicode.IsNumber(runes[end+1])) {
+		return end, false, false
+	}
+	token := tokenBefore(runes, i)
+	switch {
+	case seg.titles[strings.ToLower(token)]:
+		return end, false, false
+	case isInitial(token):
+		return end, false, false
+	case seg.abbreviations[strings.ToLower(token)]:
+		return end, capitalized, false
+	}
+
+	return end, true, false
+}
+
+// nextWordStart finds the first rune after any whitespace, opening quotes
+// and brackets that follow runes[i]. It reports false if runes end first.
+func nextWordStart(runes []rune, i int) (int, bool) {
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		if unicode.IsSpace(r) || unicode.In(r, unicode.Ps, unicode.Pi) || r == '"' {
+			continue
+		}
+		return i, true
+	}
+
+	return i, false
+}
+
+// tokenBefore returns the text between the last whitespace or opening
+// punctuation before runes[i] and runes[i].
+func tokenBefore(runes []rune, i int) string {
+	start := i
+	for start > 0 {
+		r := runes[start-1]
+		if unicode.IsSpace(r) || unicode.In(r, unicode.Ps, unicode.Pi) || r == '"' {
+			break
+		}
+		start--
+	}
+
+	return string(runes[start:i])
+}
+
+// isInitial reports whether a token is a single capital letter, as in
+// "John F. Kennedy". "I" is excluded, since it far more often ends a
+// sentence than abbreviates a name.
+func isInitial(token string) bool {
+	runes := []rune(token)
+
+	return len(runes) == 1 && unicode.IsUpper(runes[0]) && runes[0] != 'I'
+}
+
+func isPeriod(r rune) bool {
+	return r == '.' || r == '．' || r == '﹒'
+}
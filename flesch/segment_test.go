@@ -0,0 +1,102 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+func sentenceStrings(t *testing.T, text string, options ...flesch.Option) []string {
+	t.Helper()
+	document, err := flesch.ParseString(text, "segments", options...)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var sentences []string
+	for _, s := range document.Sentences {
+		sentences = append(sentences, s.String())
+	}
+
+	return sentences
+}
+
+func TestSegmentation(t *testing.T) {
+	testCases := []struct {
+		Text     string
+		Expected []string
+	}{
+		{
+			"Mr. Smith paid $3.50 at 5 p.m. on Jan. 4 for it.",
+			[]string{"Mr. Smith paid $3.50 at 5 p.m. on Jan. 4 for it."},
+		},
+		{
+			"We left at 5 p.m. Then it rained.",
+			[]string{"We left at 5 p.m.", "Then it rained."},
+		},
+		{
+			"J. R. R. Tolkien wrote it. So did I. Read it.",
+			[]string{"J. R. R. Tolkien wrote it.", "So did I.", "Read it."},
+		},
+		{
+			"See https://example.com/a.html or mail jane.doe@example.com today. Thanks!",
+			[]string{"See https://example.com/a.html or mail jane.doe@example.com today.", "Thanks!"},
+		},
+		{
+			"Well... maybe not. Wait… What?",
+			[]string{"Well... maybe not.", "Wait…", "What?"},
+		},
+		{
+			"Pack apples, pears, etc. and leave. Bring a map, a torch, etc. The rest is fine.",
+			[]string{"Pack apples, pears, etc. and leave.", "Bring a map, a torch, etc.", "The rest is fine."},
+		},
+	}
+	for _, test := range testCases {
+		sentences := sentenceStrings(t, test.Text)
+		if strings.Join(sentences, "|") != strings.Join(test.Expected, "|") {
+			t.Errorf("expected %q, got %q", test.Expected, sentences)
+		}
+	}
+}
+
+func TestSegmentationOptions(t *testing.T) {
+	text := "The Gov. said so; the Capt. agreed. Pvt. Jones did not."
+	sentences := sentenceStrings(t, text)
+	if len(sentences) != 4 {
+		t.Errorf("expected 4 sentences by default, got %q", sentences)
+	}
+
+	sentences = sentenceStrings(t, text, flesch.WithSemicolonStops(false), flesch.WithTitles("Pvt."))
+	expected := []string{"The Gov. said so; the Capt. agreed.", "Pvt. Jones did not."}
+	if strings.Join(sentences, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, sentences)
+	}
+
+	sentences = sentenceStrings(t, "Bring the approx. amount and the thingamajig. it works.", flesch.WithAbbreviations("thingamajig"))
+	if len(sentences) != 1 {
+		t.Errorf("expected 1 sentence with a custom abbreviation, got %q", sentences)
+	}
+}
+
+func TestAbbreviatedWords(t *testing.T) {
+	document, err := flesch.ParseString("We met at 5 p.m. on example.com today.", "words")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var words []string
+	for _, w := range document.Words() {
+		words = append(words, w.String())
+	}
+	if strings.Join(words, " ") != "We met at p.m on example.com today" {
+		t.Errorf("unexpected words %q", words)
+	}
+}
+
+func TestSegmentationAcrossReads(t *testing.T) {
+	// the decision after "p.m." waits for the next word, even when
+	// it only arrives with a later read
+	text := strings.Repeat("word ", 1000) + "at 5 p.m." + strings.Repeat(" ", 5000) + "on time. Done."
+	sentences := sentenceStrings(t, text)
+	if len(sentences) != 2 {
+		t.Errorf("expected 2 sentences, got %d", len(sentences))
+	}
+}
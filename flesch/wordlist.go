@@ -0,0 +1,180 @@
+package flesch
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// WordList is a list of familiar words, such as those the Dale–Chall and
+// Spache formulas consider easy. Lookups ignore case and recognize plurals,
+// possessives and -ed and -ing forms of listed words.
+type WordList struct {
+	words map[string]bool
+}
+
+// NewWordList reads a word list with one word per line. Blank lines and
+// lines starting with "#" are ignored.
+func NewWordList(r io.Reader) (*WordList, error) {
+	list := &WordList{words: make(map[string]bool)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list.words[dictionaryKey(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading word list: %w", err)
+	}
+
+	return list, nil
+}
+
+func (l *WordList) Len() int {
+	return len(l.words)
+}
+
+// Contains reports whether word, or the word it is an inflection or
+// possessive of, is in the list.
+func (l *WordList) Contains(word string) bool {
+	word = dictionaryKey(word)
+	if l.words[word] {
+		return true
+	}
+	for _, stem := range stems(word) {
+		if l.words[stem] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stems lists the words that word could be a plural, possessive, -ed or
+// -ing form of.
+func stems(word string) []string {
+	var candidates []string
+	trim := func(suffix string, replacements ...string) {
+		if !strings.HasSuffix(word, suffix) || len(word) <= len(suffix)+1 {
+			return
+		}
+		stem := strings.TrimSuffix(word, suffix)
+		for _, replacement := range replacements {
+			candidates = append(candidates, stem+replacement)
+		}
+		// stopped, running: a doubled final consonant
+		if n := len(stem); n > 2 && stem[n-1] == stem[n-2] && !isVowelByte(stem[n-1]) {
+			candidates = append(candidates, stem[:n-1])
+		}
+	}
+	trim("'s", "")
+	trim("ies", "y")
+	trim("es", "")
+	trim("s", "")
+	trim("ied", "y")
+	trim("ed", "", "e")
+	trim("ing", "", "e")
+
+	return candidates
+}
+
+func isVowelByte(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// DifficultWords lists every word of the document that is not in list.
+// Proper nouns, capitalized words that do not start a sentence, are
+// considered familiar.
+func (d Document) DifficultWords(list *WordList) []Word {
+	var difficult []Word
+	for _, s := range d.Sentences {
+		for i, w := range s.Words {
+			if i > 0 && unicode.IsUpper(w.Runes()[0]) {
+				continue
+			}
+			if !list.Contains(w.String()) {
+				difficult = append(difficult, w)
+			}
+		}
+	}
+
+	return difficult
+}
+
+// DaleChall computes the New Dale–Chall score, a grade level based on
+// the percentage of words not on the Dale–Chall list of familiar words.
+func (d Document) DaleChall() float32 {
+	difficult := float32(len(d.DifficultWords(DaleChallWords())))
+	percentDifficult := difficult / float32(d.WordCount()) * 100
+	score := .1579*percentDifficult + .0496*d.Counts().avgWordPerSen()
+	if percentDifficult > 5 {
+		score += 3.6365
+	}
+
+	return score
+}
+
+// Spache computes the revised Spache grade level, intended for primary
+// grade texts, with the embedded word list, which approximates Spache's.
+// Each unfamiliar word is only counted once.
+func (d Document) Spache() float32 {
+	return d.SpacheWith(SpacheWords())
+}
+
+// SpacheWith computes the revised Spache grade level with a list of
+// familiar words, such as Spache's published list read by NewWordList.
+func (d Document) SpacheWith(list *WordList) float32 {
+	unique := make(map[string]bool)
+	for _, w := range d.DifficultWords(list) {
+		unique[strings.ToLower(w.String())] = true
+	}
+	percentDifficult := float32(len(unique)) / float32(d.WordCount()) * 100
+
+	return .121*d.Counts().avgWordPerSen() + .082*percentDifficult + .659
+}
+
+var (
+	//go:embed data/dalechall.txt
+	daleChallList string
+	//go:embed data/spache.txt
+	spacheList string
+
+	daleChallWords     *WordList
+	loadDaleChallWords sync.Once
+	spacheWords        *WordList
+	loadSpacheWords    sync.Once
+)
+
+// DaleChallWords returns the familiar words of the New Dale–Chall formula.
+func DaleChallWords() *WordList {
+	loadDaleChallWords.Do(func() {
+		daleChallWords = mustWordList(daleChallList)
+	})
+
+	return daleChallWords
+}
+
+// SpacheWords returns the embedded familiar words of the Spache formula,
+// an approximation of Spache's revised list.
+func SpacheWords() *WordList {
+	loadSpacheWords.Do(func() {
+		spacheWords = mustWordList(spacheList)
+	})
+
+	return spacheWords
+}
+
+func mustWordList(text string) *WordList {
+	list, err := NewWordList(strings.NewReader(text))
+	if err != nil {
+		panic(fmt.Sprintf("flesch: embedded word list: %s", err))
+	}
+
+	return list
+}
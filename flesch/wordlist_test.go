@@ -0,0 +1,70 @@
+package flesch_test
+
+import (
+	"github.com/PaluMacil/flesch-index/flesch"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWordListInflections(t *testing.T) {
+	list, err := flesch.NewWordList(strings.NewReader("# comment\nbaby\nbox\nmake\nrun\nstop\nwalk\n"))
+	if err != nil {
+		t.Fatalf("reading list: %s", err)
+	}
+	if list.Len() != 6 {
+		t.Errorf("expected 6 words, got %d", list.Len())
+	}
+	familiar := []string{"Baby", "babies", "boxes", "making", "running", "stopped", "walked", "walks", "walking"}
+	for _, word := range familiar {
+		if !list.Contains(word) {
+			t.Errorf("expected %s to be familiar", word)
+		}
+	}
+	unfamiliar := []string{"comment", "stoop", "maker", "runner"}
+	for _, word := range unfamiliar {
+		if list.Contains(word) {
+			t.Errorf("expected %s to be unfamiliar", word)
+		}
+	}
+}
+
+func TestEmbeddedWordLists(t *testing.T) {
+	if n := flesch.DaleChallWords().Len(); n < 2900 {
+		t.Errorf("expected about 3,000 Dale–Chall words, got %d", n)
+	}
+	if !flesch.SpacheWords().Contains("puppies") {
+		t.Errorf("expected puppies to be a familiar Spache word")
+	}
+}
+
+func TestDaleChallAndSpache(t *testing.T) {
+	document, err := flesch.ParseString("The cat sat on the mat. Then Felix contemplated philosophy.", "familiar")
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+	var difficult []string
+	for _, w := range document.DifficultWords(flesch.DaleChallWords()) {
+		difficult = append(difficult, w.String())
+	}
+	if strings.Join(difficult, " ") != "contemplated philosophy" {
+		t.Errorf("expected 'contemplated philosophy' to be difficult, got %v", difficult)
+	}
+
+	// 2 of 10 words are difficult: .1579*20 + .0496*5 + 3.6365
+	if score := float64(document.DaleChall()); math.Abs(score-7.0425) > .01 {
+		t.Errorf("expected Dale–Chall score of 7.04, got %.2f", score)
+	}
+	// "mat" is not a Spache word either: .121*5 + .082*30 + .659
+	if score := float64(document.Spache()); math.Abs(score-3.724) > .01 {
+		t.Errorf("expected Spache score of 3.72, got %.2f", score)
+	}
+	// with a list of every word, none is difficult: .121*5 + .659
+	list, err := flesch.NewWordList(strings.NewReader("the\ncat\nsat\non\nmat\nthen\ncontemplated\nphilosophy\n"))
+	if err != nil {
+		t.Fatalf("reading list: %s", err)
+	}
+	if score := float64(document.SpacheWith(list)); math.Abs(score-1.264) > .01 {
+		t.Errorf("expected Spache score of 1.26 with a list of every word, got %.2f", score)
+	}
+}
@@ -1,5 +1,16 @@
 module github.com/PaluMacil/flesch-index
 
-go 1.13
+go 1.24.0
 
-require gonum.org/v1/plot v0.0.0-20190515093506-e2840ee46a6b
+require (
+	golang.org/x/net v0.45.0
+	gonum.org/v1/plot v0.0.0-20190515093506-e2840ee46a6b
+)
+
+require (
+	github.com/ajstarks/svgo v0.0.0-20180226025133-644b8db467af // indirect
+	github.com/fogleman/gg v1.2.1-0.20190220221249-0403632d5b90 // indirect
+	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
+	github.com/jung-kurt/gofpdf v1.0.3-0.20190309125859-24315acbbda5 // indirect
+	golang.org/x/image v0.0.0-20180708004352-c73c2afc3b81 // indirect
+)
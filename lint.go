@@ -0,0 +1,210 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io"
+	"sort"
+)
+
+// Exit codes of the lint command.
+const (
+	lintPassed    = 0
+	lintViolated  = 1
+	lintFailedRun = 2
+)
+
+// thresholds are the limits the lint command enforces. A zero threshold
+// is not checked.
+type thresholds struct {
+	minEase           float64
+	maxGrade          float64
+	maxSentenceWords  int
+	maxWordSyllables  int
+	worstOffenderRows int
+}
+
+// violation is a threshold a file fails. Sentence and word violations
+// point at their first character; document violations have no location.
+type violation struct {
+	filename string
+	pos      flesch.Position
+	message  string
+	// excess is how far past its threshold the violation is, to rank
+	// the worst offenders.
+	excess float64
+}
+
+func (v violation) String() string {
+	if !v.pos.IsValid() {
+		return fmt.Sprintf("%s: %s", v.filename, v.message)
+	}
+
+	return fmt.Sprintf("%s:%s: %s", v.filename, v.pos, v.message)
+}
+
+// runLint scores each file against the thresholds given in args, or
+// stdin if none is and it is piped, and returns the exit code: non-zero
+// when any file violates them, so that the command can fail a build.
+func runLint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var limits thresholds
+	flags.Float64Var(&limits.minEase, "min-ease", 0, "minimum Flesch reading ease score of a file")
+	flags.Float64Var(&limits.maxGrade, "max-grade", 0, "maximum Flesch–Kincaid grade level of a file")
+	flags.IntVar(&limits.maxSentenceWords, "max-sentence-words", 0, "maximum words in a sentence")
+	flags.IntVar(&limits.maxWordSyllables, "max-word-syllables", 0, "maximum syllables in a word")
+	flags.IntVar(&limits.worstOffenderRows, "worst", 10, "sentences and words to list per file, worst first; 0 lists all")
+	var parsing parserFlags
+	parsing.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return lintFailedRun
+	}
+	filenames := flags.Args()
+	if len(filenames) < 1 {
+		if !piped(stdin) {
+			fmt.Fprintln(stderr, "No file given for linting")
+			return lintFailedRun
+		}
+		filenames = []string{stdinArg}
+	}
+	options, err := parsing.options()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return lintFailedRun
+	}
+
+	code := lintPassed
+	for _, filename := range filenames {
+		document, book, err := parseFile(filename, namedReader{stdin, parsing.name}, options)
+		if err != nil {
+			fmt.Fprintln(stderr, "cannot parse file:", err)
+			return lintFailedRun
+		}
+		var violations []violation
+		if book == nil {
+			violations = lint(document, limits)
+		} else {
+			violations = lintBook(document, book.Chapters, limits)
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		code = lintViolated
+		printViolations(stdout, violations, limits.worstOffenderRows)
+	}
+
+	return code
+}
+
+// lint lists every threshold document violates.
+func lint(document flesch.Document, limits thresholds) []violation {
+	var violations []violation
+	name := document.Name()
+	ease, grade := float64(document.Score()), float64(document.Kincaid())
+	switch {
+	case limits.minEase == 0 && limits.maxGrade == 0:
+	case !finite(document.Score()) || !finite(document.Kincaid()):
+		// NaN passes every comparison, so a document without a complete
+		// sentence would pass any threshold
+		violations = append(violations, violation{
+			filename: name,
+			message:  "cannot be scored without a complete sentence",
+		})
+	default:
+		if limits.minEase != 0 && ease < limits.minEase {
+			violations = append(violations, violation{
+				filename: name,
+				message:  fmt.Sprintf("reading ease %.2f is below %.2f", ease, limits.minEase),
+				excess:   limits.minEase - ease,
+			})
+		}
+		if limits.maxGrade != 0 && grade > limits.maxGrade {
+			violations = append(violations, violation{
+				filename: name,
+				message:  fmt.Sprintf("grade level %.2f is above %.2f", grade, limits.maxGrade),
+				excess:   grade - limits.maxGrade,
+			})
+		}
+	}
+	for _, s := range document.Sentences {
+		if limits.maxSentenceWords != 0 && len(s.Words) > limits.maxSentenceWords {
+			violations = append(violations, violation{
+				filename: name,
+				pos:      s.StartPos,
+				message: fmt.Sprintf("sentence has %d words, more than %d: %q",
+					len(s.Words), limits.maxSentenceWords, shorten(analysis.HardSentence{Text: s.String()}.Excerpt())),
+				excess: float64(len(s.Words) - limits.maxSentenceWords),
+			})
+		}
+		if limits.maxWordSyllables == 0 {
+			continue
+		}
+		for _, w := range s.Words {
+			if syllables := w.Syllables(); syllables > limits.maxWordSyllables {
+				violations = append(violations, violation{
+					filename: name,
+					pos:      w.StartPos,
+					message: fmt.Sprintf("%q has %d syllables, more than %d",
+						w.String(), syllables, limits.maxWordSyllables),
+					excess: float64(syllables - limits.maxWordSyllables),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// lintBook checks the score of a whole book, and the sentences and words
+// of each chapter, whose positions are within the chapter's file.
+func lintBook(document flesch.Document, chapters []flesch.Chapter, limits thresholds) []violation {
+	documentLimits := thresholds{minEase: limits.minEase, maxGrade: limits.maxGrade}
+	violations := lint(document, documentLimits)
+	chapterLimits := limits
+	chapterLimits.minEase, chapterLimits.maxGrade = 0, 0
+	for _, chapter := range chapters {
+		violations = append(violations, lint(chapter.Document, chapterLimits)...)
+	}
+
+	return violations
+}
+
+// printViolations prints the document violations of a file followed by
+// its worst located violations, and how many more were left out.
+func printViolations(w io.Writer, violations []violation, rows int) {
+	var located []violation
+	for _, v := range violations {
+		if v.pos.IsValid() {
+			located = append(located, v)
+			continue
+		}
+		fmt.Fprintln(w, v)
+	}
+	sort.SliceStable(located, func(i, j int) bool {
+		return located[i].excess > located[j].excess
+	})
+	shown := located
+	if rows > 0 && len(shown) > rows {
+		shown = shown[:rows]
+	}
+	for _, v := range shown {
+		fmt.Fprintln(w, v)
+	}
+	if hidden := len(located) - len(shown); hidden > 0 {
+		fmt.Fprintf(w, "%s: %d more sentence and word violations\n", violations[0].filename, hidden)
+	}
+}
+
+// shorten cuts a sentence excerpt short if it is long.
+func shorten(text string) string {
+	const maxRunes = 60
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+
+	return string(runes[:maxRunes]) + "…"
+}
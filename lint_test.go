@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain.txt")
+	if err := os.WriteFile(plain, []byte("The cat sat on the mat. It was warm."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"passes", []string{"-min-ease", "60", plain}, "", lintPassed, "", ""},
+		{"ease below", []string{"-min-ease", "120", plain}, "", lintViolated, "reading ease", ""},
+		{"long sentence", []string{"-max-sentence-words", "3", plain}, "", lintViolated, "plain.txt:1:1: sentence has 6 words", ""},
+		{"stdin", []string{"-max-word-syllables", "3", "-name", "piped.txt"}, "Unquestionably complicated.", lintViolated, "piped.txt:1:1:", ""},
+		{"unscorable", []string{"-min-ease", "60", "-"}, "no terminator here", lintViolated, "cannot be scored", ""},
+		{"missing file", []string{filepath.Join(dir, "missing.txt")}, "", lintFailedRun, "", "cannot parse file"},
+		{"dictionary of another language", []string{"-language", "es", "-dictionary", plain}, "", lintFailedRun, "", "not Spanish"},
+		{"bad flag", []string{"-min-ease", "high", plain}, "", lintFailedRun, "", "invalid value"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runLint(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+			if code != test.code {
+				t.Errorf("expected exit code %d, got %d\nstdout: %s\nstderr: %s", test.code, code, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("expected stdout to contain %q, got %q", test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("expected stderr to contain %q, got %q", test.stderr, stderr.String())
+			}
+		})
+	}
+}
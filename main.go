@@ -1,42 +1,462 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"github.com/PaluMacil/flesch-index/analysis"
 	"github.com/PaluMacil/flesch-index/flesch"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func main() {
-	flagAnalysis := flag.Bool("analysis", false, "do extended analysis")
+	if len(os.Args) > 1 && (os.Args[1] == "lint" || os.Args[1] == "check") {
+		os.Exit(runLint(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
+	var s scorer
+	flag.BoolVar(&s.analyze, "analysis", false, "do extended analysis")
+	var parsing parserFlags
+	parsing.register(flag.CommandLine)
+	flagFormulas := flag.String("formulas", "ease,kincaid", "comma separated formulas to report, or \"all\" (the default for machine-readable formats)")
+	flagFormat := flag.String("format", "text", "output format: text, json, ndjson or csv")
+	flagSort := flag.String("sort", "name", "sort the summary of several files, and the documents of json, by name, words or a formula key; prefix with - to reverse")
+	flag.IntVar(&s.hardest, "hardest", 0, "list this many of the hardest sentences of each file")
+	flagHardestBy := flag.String("hardest-by", "grade", "rank the hardest sentences by grade, words or polysyllables")
+	flag.StringVar(&s.htmlDir, "html", "", "directory to write a self-contained HTML report of each file in")
+	s.charts.register(flag.CommandLine)
+	var files fileFlags
+	files.register(flag.CommandLine)
 	flag.Parse()
 
-	if len(flag.Args()) < 1 {
-		fmt.Println("No file given for analysis")
-		os.Exit(1)
+	args := flag.Args()
+	if len(args) < 1 {
+		if !piped(os.Stdin) {
+			fatal("No file given for analysis")
+		}
+		args = []string{stdinArg}
+	}
+	s.stdin = namedReader{os.Stdin, parsing.name}
+	language, err := parsing.lang()
+	if err != nil {
+		fatal(err)
+	}
+	s.options, err = parsing.options()
+	if err != nil {
+		fatal(err)
+	}
+	formulaKeys := *flagFormulas
+	if *flagFormat != "text" && !isFlagSet("formulas") {
+		formulaKeys = "all"
+	}
+	s.formulas, err = selectFormulas(formulaKeys, language)
+	if err != nil {
+		fatal(err)
+	}
+	s.hardestBy, err = analysis.ParseSentenceOrder(*flagHardestBy)
+	if err != nil {
+		fatal(err)
+	}
+	order, err := parseSummaryOrder(*flagSort, s.formulas)
+	if err != nil {
+		fatal(err)
+	}
+	if isFlagSet("sort") && !sortsResults(*flagFormat) {
+		fatal(fmt.Sprintf("-sort applies to the %v formats only, %s is written as files are scored", sortedFormats, *flagFormat))
+	}
+	writer, err := newResultWriter(*flagFormat, os.Stdout, s.formulas, order)
+	if err != nil {
+		fatal(err)
+	}
+	filter, err := files.filter()
+	if err != nil {
+		fatal(err)
+	}
+	filenames, err := expandArgs(args, filter)
+	if err != nil {
+		fatal(err)
+	}
+	if len(filenames) == 0 {
+		fatal("No file found for analysis")
+	}
+	if !isFlagSet("chart-naming") && len(filenames) > 1 {
+		// files of the same name in different directories, scored at
+		// once, would write the same charts and reports
+		s.charts.naming = "unique"
+	}
+	if s.charts.naming == "base" {
+		if a, b, ok := sameBaseName(filenames); ok {
+			fatal(fmt.Sprintf("%s and %s would overwrite each other's charts with -chart-naming base", a, b))
+		}
+	}
+	s.chartOptions, err = s.charts.options()
+	if err != nil {
+		fatal(err)
+	}
+
+	failed, err := writeOutcomes(scoreFiles(filenames, files.jobs, s.score), writer, os.Stderr)
+	if err != nil {
+		fatal("cannot write result:", err)
+	}
+	if failed > 0 {
+		fatal(fmt.Sprintf("%d of %d files could not be scored", failed, len(filenames)))
+	}
+}
+
+// writeOutcomes writes the result of each file scored, reporting those
+// that could not be on stderr so that the others, and their summary,
+// are still written. It returns how many could not, and stops at the
+// first error writing.
+func writeOutcomes(outcomes <-chan outcome, writer resultWriter, stderr io.Writer) (int, error) {
+	var failed int
+	for outcome := range outcomes {
+		if outcome.err != nil {
+			fmt.Fprintln(stderr, outcome.err)
+			failed++
+			continue
+		}
+		if err := writer.Write(outcome.result); err != nil {
+			return failed, err
+		}
+	}
+
+	return failed, writer.Close()
+}
+
+// scorer scores files as the flags of the main command say.
+type scorer struct {
+	stdin        namedReader
+	options      []flesch.Option
+	formulas     []flesch.Formula
+	hardest      int
+	hardestBy    analysis.SentenceOrder
+	analyze      bool
+	charts       chartFlags
+	chartOptions []analysis.Option
+	htmlDir      string
+}
+
+func (s scorer) score(filename string) (result, error) {
+	document, book, err := parseFile(filename, s.stdin, s.options)
+	if err != nil {
+		return result{}, fmt.Errorf("cannot parse file: %w", err)
+	}
+	r := newResult(document, s.formulas)
+	if book != nil {
+		r.Title = book.Title
+		for _, chapter := range book.Chapters {
+			chapterResult := newResult(chapter.Document, s.formulas)
+			chapterResult.Title = chapter.Title
+			r.Chapters = append(r.Chapters, chapterResult)
+		}
+	}
+
+	if s.hardest > 0 {
+		r.addHardestSentences(analysis.BuildHardestSentences(document, s.hardest, s.hardestBy))
+	}
+	if s.analyze {
+		report, err := analysis.Build(document, s.chartOptions...)
+		if err != nil {
+			return result{}, fmt.Errorf("cannot build analysis of %s: %w", document.Name(), err)
+		}
+		r.Charts = map[string]string{
+			"syllable_distribution": report.SyllableAnalysis.ChartPath,
+			"syllable_ratio":        report.SyllableRatioAnalysis.ChartPath,
+			"readability_timeline":  report.TimelineAnalysis.ChartPath,
+			"sentence_length":       report.SentenceLength.ChartPath,
+			"zipf":                  report.Vocabulary.ChartPath,
+		}
+		r.addSentenceLength(report.SentenceLength)
+		r.addVocabulary(report.Vocabulary)
+	}
+	if s.htmlDir != "" {
+		r.Report, err = writeHTMLReport(s.htmlDir, document, s.charts, s.chartOptions)
+		if err != nil {
+			return result{}, fmt.Errorf("cannot write HTML report of %s: %w", document.Name(), err)
+		}
+	}
+
+	return r, nil
+}
+
+// outcome is the result of scoring a file, or why it could not be.
+type outcome struct {
+	result result
+	err    error
+}
+
+// scoreFiles scores files with as many at once as jobs, and sends their
+// outcomes in the order of the files, each as soon as it and those
+// before it are known.
+func scoreFiles(filenames []string, jobs int, score func(filename string) (result, error)) <-chan outcome {
+	if jobs < 1 {
+		jobs = 1
+	}
+	pending := make([]chan outcome, len(filenames))
+	for i := range pending {
+		pending[i] = make(chan outcome, 1)
+	}
+	next := make(chan int)
+	go func() {
+		for i := range filenames {
+			next <- i
+		}
+		close(next)
+	}()
+	for j := 0; j < jobs; j++ {
+		go func() {
+			for i := range next {
+				r, err := score(filenames[i])
+				pending[i] <- outcome{r, err}
+			}
+		}()
+	}
+	outcomes := make(chan outcome)
+	go func() {
+		for _, p := range pending {
+			outcomes <- <-p
+		}
+		close(outcomes)
+	}()
+
+	return outcomes
+}
+
+// stdinArg is the argument that stands for standard input.
+const stdinArg = "-"
+
+// namedReader is input other than a file, with the name of its document.
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+// piped reports whether r is input piped or redirected to the program,
+// rather than a terminal that would wait for it to be typed.
+func piped(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// parseFile parses a file, or stdin for "-". An EPUB book is parsed a
+// chapter at a time, and its document is the whole book.
+func parseFile(filename string, stdin namedReader, options []flesch.Option) (flesch.Document, *flesch.Book, error) {
+	if filename == stdinArg {
+		return parseStdin(stdin, options)
+	}
+	if !strings.EqualFold(filepath.Ext(filename), ".epub") {
+		document, err := flesch.ParseFile(filename, options...)
+		return document, nil, err
+	}
+	book, err := flesch.ParseEPUB(filename, options...)
+	if err != nil {
+		return flesch.Document{}, nil, err
 	}
-	document, err := flesch.ParseFile(flag.Arg(0))
+
+	return book.Document(), &book, nil
+}
+
+// writeHTMLReport writes the HTML report of a document in dir, named
+// like its charts, and returns its path.
+func writeHTMLReport(dir string, document flesch.Document, charts chartFlags, options []analysis.Option) (string, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("ensuring creation of report directory: %w", err)
+	}
+	naming := analysis.BaseNaming
+	if charts.naming == "unique" {
+		naming = analysis.UniqueNaming
+	}
+	reportPath := filepath.Join(dir, naming(document.Name(), "Report", "html"))
+	f, err := os.Create(reportPath)
 	if err != nil {
-		fmt.Println("cannot parse file:", err)
-		os.Exit(1)
+		return "", err
+	}
+	if err := analysis.WriteHTMLReport(f, document, options...); err != nil {
+		f.Close()
+		return "", err
 	}
 
-	fmt.Println("Document:", document.Name())
-	fmt.Println()
-	fmt.Printf("Flesch Reading Ease Score: %.2f\n", document.Score())
-	fmt.Println("Readability:", document.ReadableScore())
-	fmt.Printf("Flesch–Kincaid Grade Level: %.2f\n", document.Kincaid())
+	return reportPath, f.Close()
+}
 
-	if *flagAnalysis {
-		fmt.Println()
-		fmt.Println("Detailed Analysis Follows:")
-		report, err := analysis.Build(document)
+// parseStdin parses standard input, whose format, unless its contents
+// tell, is that of a file with the name of its document. An EPUB book
+// is read whole, to be parsed a chapter at a time.
+func parseStdin(stdin namedReader, options []flesch.Option) (flesch.Document, *flesch.Book, error) {
+	format := flesch.DetectFormat(stdin.name)
+	if format == flesch.FormatEPUB {
+		source, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return flesch.Document{}, nil, fmt.Errorf("reading standard input: %w", err)
+		}
+		book, err := flesch.ReadEPUB(bytes.NewReader(source), int64(len(source)), stdin.name, options...)
 		if err != nil {
-			fmt.Println("cannot build analysis:", err)
-			os.Exit(1)
+			return flesch.Document{}, nil, fmt.Errorf("reading standard input: %w", err)
 		}
-		fmt.Println(report.SyllableAnalysis.ChartPath)
-		fmt.Println(report.SyllableRatioAnalysis.ChartPath)
+		return book.Document(), &book, nil
 	}
+	if format != "" {
+		options = append([]flesch.Option{flesch.WithFormat(format)}, options...)
+	}
+	document, err := flesch.Parse(stdin, stdin.name, options...)
+	if err != nil {
+		return document, nil, fmt.Errorf("reading standard input: %w", err)
+	}
+
+	return document, nil, nil
+}
+
+// fatal reports an error on standard error, keeping standard output
+// parseable, and exits.
+func fatal(v ...interface{}) {
+	fmt.Fprintln(os.Stderr, v...)
+	os.Exit(1)
+}
+
+// parserFlags are the flags shared by every command that parses files.
+type parserFlags struct {
+	name       string
+	language   string
+	dictionary bool
+	htmlRegion string
+	gutenberg  bool
+	headings   bool
+}
+
+func (f *parserFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.name, "name", "stdin", "name of the document read from standard input, used in output and chart file names; its extension tells its format")
+	flags.StringVar(&f.language, "language", "en", "language of the text: en, es, de, fr, nl or it")
+	flags.BoolVar(&f.dictionary, "dictionary", false, "count syllables with the pronouncing dictionary, of English only")
+	flags.StringVar(&f.htmlRegion, "html-region", "", "CSS selector of the part of HTML pages to score, e.g. main or article")
+	flags.BoolVar(&f.gutenberg, "gutenberg", false, "leave the Project Gutenberg header and license of plain text out")
+	flags.BoolVar(&f.headings, "strip-headings", false, "leave headings and tables of contents of plain text out")
+}
+
+// lang looks up the language of the flags.
+func (f parserFlags) lang() (*flesch.Language, error) {
+	language, ok := flesch.LookupLanguage(f.language)
+	if !ok {
+		return nil, fmt.Errorf("unknown language %q", f.language)
+	}
+
+	return language, nil
+}
+
+// options turns the flags into parser options.
+func (f parserFlags) options() ([]flesch.Option, error) {
+	language, err := f.lang()
+	if err != nil {
+		return nil, err
+	}
+	options := []flesch.Option{flesch.WithLanguage(language)}
+	if f.dictionary {
+		if language != flesch.English {
+			return nil, fmt.Errorf("-dictionary is of English pronunciations, not %s", language.Name)
+		}
+		options = append(options, flesch.WithSyllableCounter(flesch.CMUCounter()))
+	}
+	if f.htmlRegion != "" {
+		options = append(options, flesch.WithHTMLRegion(f.htmlRegion))
+	}
+	if f.gutenberg {
+		options = append(options, flesch.WithGutenbergStripping())
+	}
+	if f.headings {
+		options = append(options, flesch.WithHeadingStripping())
+	}
+
+	return options, nil
+}
+
+// chartFlags are the flags that say how and where charts are saved.
+type chartFlags struct {
+	directory string
+	format    string
+	naming    string
+	dpi       int
+}
+
+func (f *chartFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.directory, "chart-dir", "", "directory to save charts in (default ~/.flesch-index-data)")
+	flags.StringVar(&f.format, "chart-format", "png", "image format of charts: png, svg, pdf or eps")
+	flags.StringVar(&f.naming, "chart-naming", "base", "name charts after the file's base name (base) or its path too (unique, the default for several files)")
+	flags.IntVar(&f.dpi, "chart-dpi", 96, "resolution of PNG charts")
+}
+
+// sameBaseName finds two files whose charts BaseNaming names alike.
+func sameBaseName(filenames []string) (string, string, bool) {
+	seen := make(map[string]string)
+	for _, filename := range filenames {
+		name := analysis.BaseNaming(filename, "", analysis.PNG)
+		if other, ok := seen[name]; ok {
+			return other, filename, true
+		}
+		seen[name] = filename
+	}
+
+	return "", "", false
+}
+
+// options turns the flags into chart options.
+func (f chartFlags) options() ([]analysis.Option, error) {
+	format, err := analysis.ParseImageFormat(f.format)
+	if err != nil {
+		return nil, err
+	}
+	options := []analysis.Option{analysis.WithImageFormat(format), analysis.WithDPI(f.dpi)}
+	switch f.naming {
+	case "base":
+	case "unique":
+		options = append(options, analysis.WithNaming(analysis.UniqueNaming))
+	default:
+		return nil, fmt.Errorf("unknown chart naming %q, expected base or unique", f.naming)
+	}
+	if f.directory != "" {
+		options = append(options, analysis.WithOutputDirectory(f.directory))
+	}
+
+	return options, nil
+}
+
+func isFlagSet(name string) bool {
+	var set bool
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+// selectFormulas looks up the formulas of comma separated keys, or for
+// "all", every formula that applies to the language.
+func selectFormulas(keys string, language *flesch.Language) ([]flesch.Formula, error) {
+	var formulas []flesch.Formula
+	if keys == "all" {
+		for _, formula := range flesch.Formulas() {
+			if formula.AppliesTo(language) {
+				formulas = append(formulas, formula)
+			}
+		}
+		return formulas, nil
+	}
+	for _, key := range strings.Split(keys, ",") {
+		formula, ok := flesch.LookupFormula(strings.TrimSpace(key))
+		if !ok {
+			return nil, fmt.Errorf("unknown formula %q", key)
+		}
+		formulas = append(formulas, formula)
+	}
+
+	return formulas, nil
 }
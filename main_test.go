@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScoreFilesKeepsGoing(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":       "Education is important. Children learn quickly.",
+		"broken.docx": "not a zip file",
+		"c.txt":       "The committee deliberated for several hours.",
+	}
+	for name, text := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filenames, err := expandArgs([]string{dir}, fileFilter{})
+	if err != nil {
+		t.Fatalf("expanding: %s", err)
+	}
+	s := scorer{formulas: selectFormulasOrFail(t, "ease,kincaid")}
+	var stdout, stderr bytes.Buffer
+	writer, _ := newResultWriter("text", &stdout, s.formulas, summaryOrder{key: "name"})
+	failed, err := writeOutcomes(scoreFiles(filenames, 2, s.score), writer, &stderr)
+	if err != nil {
+		t.Fatalf("writing: %s", err)
+	}
+	if failed != 1 || !strings.Contains(stderr.String(), "broken.docx") {
+		t.Errorf("expected broken.docx to fail alone, got %d failures: %s", failed, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Summary of 2 documents") {
+		t.Errorf("expected a summary of the other files, got\n%s", stdout.String())
+	}
+}
+
+func selectFormulasOrFail(t *testing.T, keys string) []flesch.Formula {
+	t.Helper()
+	formulas, err := selectFormulas(keys, flesch.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return formulas
+}
+
+func TestSameBaseName(t *testing.T) {
+	tests := []struct {
+		filenames []string
+		same      bool
+	}{
+		{[]string{"a/doc.txt", "b/other.txt"}, false},
+		{[]string{"a/doc.txt", "b/doc.txt"}, true},
+		{[]string{"a/doc.txt", "a/doc.md"}, true},
+		{[]string{"doc.txt"}, false},
+	}
+	for _, test := range tests {
+		if _, _, same := sameBaseName(test.filenames); same != test.same {
+			t.Errorf("%v: expected same base name %t, got %t", test.filenames, test.same, same)
+		}
+	}
+}
+
+func TestParseStdin(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		words string
+	}{
+		// without a known extension, stdin is plain text
+		{"stdin", "The cat &amp; dog sat.", "The cat amp dog sat"},
+		{"page.html", "<p>The cat &amp; dog sat.</p>", "The cat dog sat"},
+		{"PAGE.HTM", "<p>The cat &amp; dog sat.</p>", "The cat dog sat"},
+		{"notes.md", "The **cat** sat [here](http://example.com/a.b).", "The cat sat here"},
+		{"notes.txt", "The cat sat [here](http://example.com/a.b).", "The cat sat here http://example.com/a.b"},
+	}
+	for _, test := range tests {
+		document, book, err := parseFile(stdinArg, namedReader{strings.NewReader(test.input), test.name}, nil)
+		if err != nil || book != nil {
+			t.Errorf("%s: expected a document, got book %v and error %v", test.name, book, err)
+			continue
+		}
+		var words []string
+		for _, word := range document.Words() {
+			words = append(words, word.String())
+		}
+		if strings.Join(words, " ") != test.words || document.Name() != test.name {
+			t.Errorf("%s: expected words %q, got %q named %s", test.name, test.words, strings.Join(words, " "), document.Name())
+		}
+	}
+
+	// an EPUB name reads stdin as a book
+	if _, _, err := parseFile(stdinArg, namedReader{strings.NewReader("not a zip"), "book.epub"}, nil); err == nil || !strings.Contains(err.Error(), "zip") {
+		t.Errorf("expected stdin named book.epub to be read as a book, got %v", err)
+	}
+}
+
+func TestSelectFormulas(t *testing.T) {
+	tests := []struct {
+		keys     string
+		language *flesch.Language
+		expected []string
+	}{
+		{"all", flesch.English, []string{"ease", "kincaid", "fog", "smog", "coleman-liau", "ari", "linsear", "forcast", "dale-chall", "spache"}},
+		{"all", flesch.Spanish, []string{"ease", "fernandez-huerta", "szigriszt-pazos"}},
+		{"all", flesch.German, []string{"ease", "amstad"}},
+		// formulas asked for by key are kept whatever the language
+		{"ease, douma", flesch.English, []string{"ease", "douma"}},
+	}
+	for _, test := range tests {
+		formulas, err := selectFormulas(test.keys, test.language)
+		if err != nil {
+			t.Errorf("%s of %s: %s", test.keys, test.language.Name, err)
+			continue
+		}
+		var keys []string
+		for _, formula := range formulas {
+			keys = append(keys, formula.Key)
+		}
+		if strings.Join(keys, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("%s of %s: expected %v, got %v", test.keys, test.language.Name, test.expected, keys)
+		}
+	}
+	if _, err := selectFormulas("ease,flesh", flesch.English); err == nil {
+		t.Errorf("expected an unknown formula to be an error")
+	}
+}
+
+func TestScoreStdinErrorsName(t *testing.T) {
+	// a document without a sentence has no charts to build
+	s := scorer{
+		stdin:        namedReader{strings.NewReader(""), "notes.md"},
+		formulas:     selectFormulasOrFail(t, "ease"),
+		analyze:      true,
+		chartOptions: []analysis.Option{analysis.WithChartBytes()},
+	}
+	_, err := s.score(stdinArg)
+	if err == nil || !strings.Contains(err.Error(), "analysis of notes.md:") {
+		t.Errorf("expected an error naming notes.md, got %v", err)
+	}
+}
@@ -0,0 +1,699 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/PaluMacil/flesch-index/analysis"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// schemaVersion is written with every result of the machine-readable
+// formats. It changes whenever a field is renamed, removed or changes
+// meaning; adding a field does not change it.
+const schemaVersion = 1
+
+// result is the schema of a scored document in the JSON, NDJSON and
+// CSV formats.
+type result struct {
+	SchemaVersion int                `json:"schema_version"`
+	Document      string             `json:"document"`
+	Title         string             `json:"title,omitempty"`
+	Language      string             `json:"language"`
+	Counts        resultCounts       `json:"counts"`
+	Scores        map[string]float64 `json:"scores"`
+	Readability   string             `json:"readability"`
+	Charts        map[string]string  `json:"charts,omitempty"`
+	// Report is the path of the HTML report, with -html
+	Report string `json:"report,omitempty"`
+	// SentenceLength describes the lengths of sentences, with -analysis
+	SentenceLength *resultSentenceLength `json:"sentence_length,omitempty"`
+	// Vocabulary measures the richness of the vocabulary, with -analysis
+	Vocabulary *resultVocabulary `json:"vocabulary,omitempty"`
+	Chapters   []result          `json:"chapters,omitempty"`
+	Stripped   []resultStripped  `json:"stripped,omitempty"`
+	// HardestBy is what HardestSentences are ranked by
+	HardestBy        string           `json:"hardest_by,omitempty"`
+	HardestSentences []resultSentence `json:"hardest_sentences,omitempty"`
+
+	// formulas are the formulas of Scores, in the order to print them
+	formulas []flesch.Formula
+}
+
+type resultCounts struct {
+	Sentences     int `json:"sentences"`
+	Words         int `json:"words"`
+	Syllables     int `json:"syllables"`
+	Characters    int `json:"characters"`
+	Monosyllables int `json:"monosyllables"`
+	Polysyllables int `json:"polysyllables"`
+	ComplexWords  int `json:"complex_words"`
+}
+
+// resultStripped is a range of lines left out of scoring.
+type resultStripped struct {
+	Reason    string `json:"reason"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+// resultSentenceLength summarizes the words per sentence.
+type resultSentenceLength struct {
+	Mean              float64 `json:"mean"`
+	Median            float64 `json:"median"`
+	StandardDeviation float64 `json:"standard_deviation"`
+	// Percentiles by name, like "p90"
+	Percentiles map[string]float64 `json:"percentiles"`
+	Outliers    int                `json:"outliers"`
+}
+
+// resultVocabulary measures lexical diversity. Words are case folded.
+type resultVocabulary struct {
+	Tokens         int     `json:"tokens"`
+	Types          int     `json:"types"`
+	TypeTokenRatio float64 `json:"type_token_ratio"`
+	MTLD           float64 `json:"mtld"`
+	HDD            float64 `json:"hdd"`
+	HapaxLegomena  int     `json:"hapax_legomena"`
+	DisLegomena    int     `json:"dis_legomena"`
+	// TopWords are the most frequent words, the most frequent first
+	TopWords []analysis.WordFrequency `json:"top_words"`
+}
+
+// topWords is the number of the most frequent words reported.
+const topWords = 10
+
+// resultSentence is one of the hardest sentences of a document.
+type resultSentence struct {
+	Line          int     `json:"line"`
+	Column        int     `json:"column"`
+	Grade         float64 `json:"grade"`
+	Ease          float64 `json:"ease"`
+	Words         int     `json:"words"`
+	Polysyllables int     `json:"polysyllables"`
+	Text          string  `json:"text"`
+}
+
+func newResult(document flesch.Document, formulas []flesch.Formula) result {
+	counts := document.Counts()
+	r := result{
+		SchemaVersion: schemaVersion,
+		Document:      document.Name(),
+		Language:      document.Language().Code,
+		Counts: resultCounts{
+			Sentences:     counts.Sentences,
+			Words:         counts.Words,
+			Syllables:     counts.Syllables,
+			Characters:    counts.Characters,
+			Monosyllables: counts.Monosyllables,
+			Polysyllables: counts.Polysyllables,
+			ComplexWords:  counts.ComplexWords,
+		},
+		Scores:      make(map[string]float64),
+		Readability: unscorable,
+		formulas:    formulas,
+	}
+	for _, formula := range formulas {
+		if score := formula.Compute(document); finite(score) {
+			r.Scores[formula.Key] = round(score)
+		}
+	}
+	if ease, ok := flesch.LookupFormula("ease"); ok {
+		if score := ease.Compute(document); finite(score) {
+			r.Readability = ease.Band(score)
+		}
+	}
+	for _, stripped := range document.Stripped {
+		r.Stripped = append(r.Stripped, resultStripped{
+			Reason:    string(stripped.Reason),
+			StartLine: stripped.Start.Line,
+			EndLine:   stripped.End.Line,
+		})
+	}
+
+	return r
+}
+
+func (r *result) addHardestSentences(hardest analysis.HardestSentencesAnalysis) {
+	r.HardestBy = string(hardest.Order)
+	for _, sentence := range hardest.Sentences {
+		r.HardestSentences = append(r.HardestSentences, resultSentence{
+			Line:          sentence.Start.Line,
+			Column:        sentence.Start.Column,
+			Grade:         round(sentence.Grade),
+			Ease:          round(sentence.Ease),
+			Words:         sentence.Words,
+			Polysyllables: sentence.Polysyllables,
+			Text:          sentence.Excerpt(),
+		})
+	}
+}
+
+func (r *result) addSentenceLength(lengths analysis.SentenceLengthAnalysis) {
+	r.SentenceLength = &resultSentenceLength{
+		Mean:              math.Round(lengths.Mean*1e4) / 1e4,
+		Median:            lengths.Median,
+		StandardDeviation: math.Round(lengths.StandardDeviation*1e4) / 1e4,
+		Percentiles:       make(map[string]float64),
+		Outliers:          len(lengths.Outliers),
+	}
+	for p, length := range lengths.Percentiles {
+		r.SentenceLength.Percentiles[fmt.Sprintf("p%d", p)] = math.Round(length*1e4) / 1e4
+	}
+}
+
+func (r *result) addVocabulary(vocabulary analysis.VocabularyAnalysis) {
+	r.Vocabulary = &resultVocabulary{
+		Tokens:         vocabulary.Tokens,
+		Types:          vocabulary.Types,
+		TypeTokenRatio: math.Round(vocabulary.TypeTokenRatio*1e4) / 1e4,
+		MTLD:           math.Round(vocabulary.MTLD*1e4) / 1e4,
+		HDD:            math.Round(vocabulary.HDD*1e4) / 1e4,
+		HapaxLegomena:  vocabulary.HapaxLegomena,
+		DisLegomena:    vocabulary.DisLegomena,
+		TopWords:       vocabulary.Frequencies,
+	}
+	if len(r.Vocabulary.TopWords) > topWords {
+		r.Vocabulary.TopWords = r.Vocabulary.TopWords[:topWords]
+	}
+}
+
+func (c resultCounts) counts() flesch.Counts {
+	return flesch.Counts{
+		Sentences:     c.Sentences,
+		Words:         c.Words,
+		Syllables:     c.Syllables,
+		Characters:    c.Characters,
+		Monosyllables: c.Monosyllables,
+		Polysyllables: c.Polysyllables,
+		ComplexWords:  c.ComplexWords,
+	}
+}
+
+// corpus merges the counts of several documents, to score them as one
+// rather than average their scores.
+type corpus struct {
+	documents int
+	counts    flesch.Counts
+	language  *flesch.Language
+}
+
+func (c *corpus) add(r result) {
+	c.documents++
+	c.counts.Merge(r.Counts.counts())
+	if c.language == nil {
+		c.language, _ = flesch.LookupLanguage(r.Language)
+	}
+}
+
+// resultCorpus is the schema of the totals of every document in the
+// JSON format. Formulas that need the text have no score.
+type resultCorpus struct {
+	Documents   int                `json:"documents"`
+	Counts      resultCounts       `json:"counts"`
+	Scores      map[string]float64 `json:"scores"`
+	Readability string             `json:"readability"`
+}
+
+func (c corpus) result(formulas []flesch.Formula) resultCorpus {
+	r := resultCorpus{
+		Documents: c.documents,
+		Counts: resultCounts{
+			Sentences:     c.counts.Sentences,
+			Words:         c.counts.Words,
+			Syllables:     c.counts.Syllables,
+			Characters:    c.counts.Characters,
+			Monosyllables: c.counts.Monosyllables,
+			Polysyllables: c.counts.Polysyllables,
+			ComplexWords:  c.counts.ComplexWords,
+		},
+		Scores: make(map[string]float64),
+	}
+	for _, formula := range formulas {
+		if formula.FromCounts == nil {
+			continue
+		}
+		if score := formula.FromCounts(c.counts, c.language); finite(score) {
+			r.Scores[formula.Key] = round(score)
+		}
+	}
+	r.Readability = unscorable
+	if ease, ok := flesch.LookupFormula("ease"); ok {
+		if score := ease.FromCounts(c.counts, c.language); finite(score) {
+			r.Readability = ease.Band(score)
+		}
+	}
+
+	return r
+}
+
+// summaryOrder is what the summary of several documents, and the
+// documents of the JSON format, are sorted by: their name, words or the
+// score of a formula. The formats written as results come cannot be
+// sorted.
+type summaryOrder struct {
+	key        string
+	descending bool
+}
+
+func parseSummaryOrder(s string, formulas []flesch.Formula) (summaryOrder, error) {
+	order := summaryOrder{key: strings.TrimPrefix(s, "-"), descending: strings.HasPrefix(s, "-")}
+	if order.key == "name" || order.key == "words" {
+		return order, nil
+	}
+	for _, formula := range formulas {
+		if formula.Key == order.key {
+			return order, nil
+		}
+	}
+
+	return summaryOrder{}, fmt.Errorf("cannot sort by %q, expected name, words or one of the formulas reported", order.key)
+}
+
+func (o summaryOrder) String() string {
+	if o.descending {
+		return o.key + ", descending"
+	}
+
+	return o.key
+}
+
+func (o summaryOrder) sort(results []result) {
+	less := func(a, b result) bool {
+		switch o.key {
+		case "name":
+			return a.Document < b.Document
+		case "words":
+			return a.Counts.Words < b.Counts.Words
+		}
+		return a.Scores[o.key] < b.Scores[o.key]
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		// documents without a score come last either way
+		if o.key != "name" && o.key != "words" {
+			_, iScored := results[i].Scores[o.key]
+			_, jScored := results[j].Scores[o.key]
+			if iScored != jScored {
+				return iScored
+			}
+		}
+		if o.descending {
+			return less(results[j], results[i])
+		}
+		return less(results[i], results[j])
+	})
+}
+
+// unscorable is the readability of a document without a sentence or a
+// word, whose scores are left out.
+const unscorable = "unavailable"
+
+// finite reports whether a score is a number, which it is not for a
+// document without a sentence or a word to divide by.
+func finite(score float32) bool {
+	return !math.IsNaN(float64(score)) && !math.IsInf(float64(score), 0)
+}
+
+// formatScore formats a score for the text format, or "-" if there is
+// none.
+func formatScore(scores map[string]float64, key string) string {
+	score, ok := scores[key]
+	if !ok {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f", score)
+}
+
+// round keeps the digits of a score that float32 can be trusted with.
+func round(score float32) float64 {
+	return math.Round(float64(score)*1e4) / 1e4
+}
+
+// resultWriter writes results in one of the output formats.
+type resultWriter interface {
+	Write(r result) error
+	// Close writes anything held back until all results are known.
+	Close() error
+}
+
+var formats = []string{"text", "json", "ndjson", "csv"}
+
+// sortedFormats are the formats that sort their results, and total them.
+var sortedFormats = []string{"text", "json"}
+
+func sortsResults(format string) bool {
+	for _, sorted := range sortedFormats {
+		if format == sorted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newResultWriter(format string, w io.Writer, formulas []flesch.Formula, order summaryOrder) (resultWriter, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w, order: order}, nil
+	case "json":
+		return &jsonWriter{w: w, order: order}, nil
+	case "ndjson":
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w), formulas: formulas}, nil
+	}
+
+	return nil, fmt.Errorf("unknown format %q, expected one of %v", format, formats)
+}
+
+// textWriter writes each result for people to read, and a summary of
+// them all if there are several.
+type textWriter struct {
+	w       io.Writer
+	order   summaryOrder
+	results []result
+}
+
+func (t *textWriter) Write(r result) error {
+	if len(t.results) > 0 {
+		fmt.Fprintln(t.w)
+	}
+	t.results = append(t.results, r)
+	fmt.Fprintln(t.w, "Document:", r.Document)
+	fmt.Fprintln(t.w)
+	for _, formula := range r.formulas {
+		fmt.Fprintf(t.w, "%s: %s\n", formula.Name, formatScore(r.Scores, formula.Key))
+		if formula.Key == "ease" {
+			fmt.Fprintln(t.w, "Readability:", r.Readability)
+		}
+	}
+	if len(r.Stripped) > 0 {
+		fmt.Fprintln(t.w)
+		t.writeStripped(r)
+	}
+	if len(r.Chapters) > 0 {
+		fmt.Fprintln(t.w)
+		t.writeChapters(r)
+	}
+	if len(r.HardestSentences) > 0 {
+		fmt.Fprintln(t.w)
+		t.writeHardestSentences(r)
+	}
+	if r.SentenceLength != nil {
+		fmt.Fprintln(t.w)
+		t.writeSentenceLength(r)
+	}
+	if r.Vocabulary != nil {
+		fmt.Fprintln(t.w)
+		t.writeVocabulary(r)
+	}
+	if r.Report != "" {
+		fmt.Fprintln(t.w)
+		fmt.Fprintln(t.w, "HTML report:", r.Report)
+	}
+	if len(r.Charts) > 0 {
+		fmt.Fprintln(t.w)
+		fmt.Fprintln(t.w, "Detailed Analysis Follows:")
+		for _, name := range chartNames(r.Charts) {
+			fmt.Fprintln(t.w, r.Charts[name])
+		}
+	}
+
+	return nil
+}
+
+// writeStripped summarizes what was left out of scoring, giving the
+// lines of a reason's range unless there are several.
+func (t *textWriter) writeStripped(r result) {
+	var reasons []string
+	ranges := make(map[string][]resultStripped)
+	for _, stripped := range r.Stripped {
+		if ranges[stripped.Reason] == nil {
+			reasons = append(reasons, stripped.Reason)
+		}
+		ranges[stripped.Reason] = append(ranges[stripped.Reason], stripped)
+	}
+	for _, reason := range reasons {
+		if stripped := ranges[reason]; len(stripped) == 1 {
+			fmt.Fprintf(t.w, "Stripped %s: lines %d-%d\n", reason, stripped[0].StartLine, stripped[0].EndLine)
+		} else {
+			fmt.Fprintf(t.w, "Stripped %s: %d ranges\n", reason, len(stripped))
+		}
+	}
+}
+
+// writeChapters writes a table of the chapters of a book, to show where
+// it gets harder.
+func (t *textWriter) writeChapters(r result) {
+	if r.Title != "" {
+		fmt.Fprintln(t.w, "Chapters of", r.Title)
+	} else {
+		fmt.Fprintln(t.w, "Chapters")
+	}
+	table := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(table, "#\tWords\t")
+	for _, formula := range r.formulas {
+		fmt.Fprintf(table, "%s\t", formula.Key)
+	}
+	// right aligned cells are padded on the left, so the titles need a gap
+	fmt.Fprintln(table, "  Chapter")
+	for i, chapter := range r.Chapters {
+		fmt.Fprintf(table, "%d\t%d\t", i+1, chapter.Counts.Words)
+		for _, formula := range r.formulas {
+			fmt.Fprintf(table, "%s\t", formatScore(chapter.Scores, formula.Key))
+		}
+		fmt.Fprintln(table, "  "+chapter.Title)
+	}
+	table.Flush()
+}
+
+// writeSentenceLength summarizes the words per sentence.
+func (t *textWriter) writeSentenceLength(r result) {
+	lengths := r.SentenceLength
+	fmt.Fprintf(t.w, "Words per sentence: mean %.2f, median %.1f, standard deviation %.2f\n",
+		lengths.Mean, lengths.Median, lengths.StandardDeviation)
+	var percentiles []string
+	for _, p := range analysis.Percentiles {
+		percentiles = append(percentiles, fmt.Sprintf("%d%%: %.1f", p, lengths.Percentiles[fmt.Sprintf("p%d", p)]))
+	}
+	fmt.Fprintln(t.w, "Percentiles:", strings.Join(percentiles, ", "))
+	fmt.Fprintln(t.w, "Outlying sentences:", lengths.Outliers)
+}
+
+// writeVocabulary summarizes the richness of the vocabulary.
+func (t *textWriter) writeVocabulary(r result) {
+	vocabulary := r.Vocabulary
+	fmt.Fprintf(t.w, "Vocabulary: %d words, %d distinct, type-token ratio %.4f\n",
+		vocabulary.Tokens, vocabulary.Types, vocabulary.TypeTokenRatio)
+	fmt.Fprintf(t.w, "Lexical diversity: MTLD %.2f, HD-D %.4f\n", vocabulary.MTLD, vocabulary.HDD)
+	fmt.Fprintf(t.w, "Words used once: %d, twice: %d\n", vocabulary.HapaxLegomena, vocabulary.DisLegomena)
+	var top []string
+	for _, frequency := range vocabulary.TopWords {
+		top = append(top, fmt.Sprintf("%s (%d)", frequency.Word, frequency.Count))
+	}
+	fmt.Fprintln(t.w, "Most frequent:", strings.Join(top, ", "))
+}
+
+// writeHardestSentences lists the sentences most worth rewriting, each
+// under a line of what makes it hard.
+func (t *textWriter) writeHardestSentences(r result) {
+	fmt.Fprintf(t.w, "Hardest sentences by %s\n", r.HardestBy)
+	for i, sentence := range r.HardestSentences {
+		fmt.Fprintf(t.w, "%d. %d:%d  grade %.2f, ease %.2f, %d words, %d polysyllables\n",
+			i+1, sentence.Line, sentence.Column, sentence.Grade, sentence.Ease, sentence.Words, sentence.Polysyllables)
+		fmt.Fprintf(t.w, "   %s\n", sentence.Text)
+	}
+}
+
+// writeSummary writes a table of every result, sorted, and the scores of
+// their counts merged. Formulas that need the text have no total.
+func (t *textWriter) writeSummary() {
+	results := make([]result, len(t.results))
+	copy(results, t.results)
+	t.order.sort(results)
+	formulas := results[0].formulas
+	var total corpus
+	for _, r := range results {
+		total.add(r)
+	}
+
+	fmt.Fprintf(t.w, "Summary of %d documents by %s\n", len(results), t.order)
+	table := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(table, "Words\t")
+	for _, formula := range formulas {
+		fmt.Fprintf(table, "%s\t", formula.Key)
+	}
+	// right aligned cells are padded on the left, so the names need a gap
+	fmt.Fprintln(table, "  Document")
+	for _, r := range results {
+		fmt.Fprintf(table, "%d\t", r.Counts.Words)
+		for _, formula := range formulas {
+			fmt.Fprintf(table, "%s\t", formatScore(r.Scores, formula.Key))
+		}
+		fmt.Fprintln(table, "  "+r.Document)
+	}
+	totals := total.result(formulas)
+	fmt.Fprintf(table, "%d\t", totals.Counts.Words)
+	for _, formula := range formulas {
+		fmt.Fprintf(table, "%s\t", formatScore(totals.Scores, formula.Key))
+	}
+	fmt.Fprintln(table, "  Total")
+	table.Flush()
+	fmt.Fprintln(t.w, "Total readability:", totals.Readability)
+}
+
+func (t *textWriter) Close() error {
+	if len(t.results) > 1 {
+		fmt.Fprintln(t.w)
+		t.writeSummary()
+	}
+
+	return nil
+}
+
+// jsonWriter writes a single JSON object holding every result, sorted,
+// and their totals if there are several.
+type jsonWriter struct {
+	w       io.Writer
+	order   summaryOrder
+	results []result
+}
+
+func (j *jsonWriter) Write(r result) error {
+	j.results = append(j.results, r)
+
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+	results := j.results
+	if results == nil {
+		results = []result{}
+	}
+	var totals *resultCorpus
+	if len(results) > 1 {
+		j.order.sort(results)
+		var total corpus
+		for _, r := range results {
+			total.add(r)
+		}
+		corpusResult := total.result(results[0].formulas)
+		totals = &corpusResult
+	}
+
+	return encoder.Encode(struct {
+		SchemaVersion int           `json:"schema_version"`
+		Documents     []result      `json:"documents"`
+		Corpus        *resultCorpus `json:"corpus,omitempty"`
+	}{schemaVersion, results, totals})
+}
+
+// ndjsonWriter writes each result on its own line as soon as it is known.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(r result) error {
+	return n.encoder.Encode(r)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// csvWriter writes a header and then a row per result, followed by a
+// row per chapter if the result is a book, telling the book it is a
+// chapter of. Score columns follow the order of the selected formulas,
+// and chart columns are named after the charts of the first result.
+type csvWriter struct {
+	w        *csv.Writer
+	formulas []flesch.Formula
+	charts   []string
+	written  bool
+}
+
+func (c *csvWriter) Write(r result) error {
+	if err := c.write(r, ""); err != nil {
+		return err
+	}
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+// write writes the row of a result, and those of its chapters, whose
+// chapter_of column is the document of the book.
+func (c *csvWriter) write(r result, chapterOf string) error {
+	if !c.written {
+		c.written = true
+		c.charts = chartNames(r.Charts)
+		header := []string{
+			"schema_version", "document", "language",
+			"sentences", "words", "syllables", "characters",
+			"monosyllables", "polysyllables", "complex_words",
+		}
+		for _, formula := range c.formulas {
+			header = append(header, formula.Key)
+		}
+		header = append(header, "readability", "title", "chapter_of")
+		for _, chart := range c.charts {
+			header = append(header, chart+"_chart")
+		}
+		if err := c.w.Write(header); err != nil {
+			return err
+		}
+	}
+	row := []string{
+		strconv.Itoa(r.SchemaVersion), r.Document, r.Language,
+		strconv.Itoa(r.Counts.Sentences), strconv.Itoa(r.Counts.Words),
+		strconv.Itoa(r.Counts.Syllables), strconv.Itoa(r.Counts.Characters),
+		strconv.Itoa(r.Counts.Monosyllables), strconv.Itoa(r.Counts.Polysyllables),
+		strconv.Itoa(r.Counts.ComplexWords),
+	}
+	for _, formula := range c.formulas {
+		if score, ok := r.Scores[formula.Key]; ok {
+			row = append(row, strconv.FormatFloat(score, 'f', -1, 64))
+		} else {
+			row = append(row, "")
+		}
+	}
+	row = append(row, r.Readability, r.Title, chapterOf)
+	for _, chart := range c.charts {
+		row = append(row, r.Charts[chart])
+	}
+	if err := c.w.Write(row); err != nil {
+		return err
+	}
+	for _, chapter := range r.Chapters {
+		if err := c.write(chapter, r.Document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func chartNames(charts map[string]string) []string {
+	var names []string
+	for name := range charts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/PaluMacil/flesch-index/flesch"
+	"strings"
+	"testing"
+)
+
+// writeResults writes results in a format and returns the output.
+func writeResults(t *testing.T, format string, results ...result) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := newResultWriter(format, &buf, flesch.Formulas(), summaryOrder{key: "name"})
+	if err != nil {
+		t.Fatalf("creating %s writer: %s", format, err)
+	}
+	for _, r := range results {
+		if err := writer.Write(r); err != nil {
+			t.Fatalf("writing %s: %s", format, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("closing %s: %s", format, err)
+	}
+
+	return buf.String()
+}
+
+func parseResult(t *testing.T, text, name string) result {
+	t.Helper()
+	document, err := flesch.ParseString(text, name)
+	if err != nil {
+		t.Fatalf("parsing: %s", err)
+	}
+
+	return newResult(document, flesch.Formulas())
+}
+
+func TestResultSchema(t *testing.T) {
+	r := parseResult(t, "Education is important. Children learn quickly.", "schema")
+	var decoded struct {
+		SchemaVersion int `json:"schema_version"`
+		Documents     []map[string]json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(writeResults(t, "json", r)), &decoded); err != nil {
+		t.Fatalf("decoding: %s", err)
+	}
+	if decoded.SchemaVersion != schemaVersion || len(decoded.Documents) != 1 {
+		t.Fatalf("expected schema version %d and 1 document, got %d and %d",
+			schemaVersion, decoded.SchemaVersion, len(decoded.Documents))
+	}
+	for _, field := range []string{"schema_version", "document", "language", "counts", "scores", "readability"} {
+		if _, ok := decoded.Documents[0][field]; !ok {
+			t.Errorf("expected field %q", field)
+		}
+	}
+	var scores map[string]float64
+	if err := json.Unmarshal(decoded.Documents[0]["scores"], &scores); err != nil {
+		t.Fatalf("decoding scores: %s", err)
+	}
+	if len(scores) != len(flesch.Formulas()) {
+		t.Errorf("expected %d scores, got %v", len(flesch.Formulas()), scores)
+	}
+}
+
+func TestEmptyDocument(t *testing.T) {
+	for _, text := range []string{"", "no terminator here"} {
+		r := parseResult(t, text, "empty")
+		if len(r.Scores) != 0 || r.Readability != unscorable {
+			t.Errorf("%q: expected no scores and readability %q, got %v and %q", text, unscorable, r.Scores, r.Readability)
+		}
+
+		var decoded struct {
+			Documents []result
+			Corpus    *resultCorpus
+		}
+		if err := json.Unmarshal([]byte(writeResults(t, "json", r, r)), &decoded); err != nil {
+			t.Errorf("%q: decoding json: %s", text, err)
+		} else if decoded.Corpus == nil || len(decoded.Corpus.Scores) != 0 || decoded.Corpus.Readability != unscorable {
+			t.Errorf("%q: expected a corpus without scores, got %+v", text, decoded.Corpus)
+		}
+		if err := json.Unmarshal([]byte(writeResults(t, "ndjson", r)), &result{}); err != nil {
+			t.Errorf("%q: decoding ndjson: %s", text, err)
+		}
+
+		rows, err := csv.NewReader(strings.NewReader(writeResults(t, "csv", r))).ReadAll()
+		if err != nil {
+			t.Fatalf("%q: reading csv: %s", text, err)
+		}
+		for i, column := range rows[0] {
+			if column == "ease" && rows[1][i] != "" {
+				t.Errorf("%q: expected an empty ease cell, got %q", text, rows[1][i])
+			}
+		}
+
+		output := writeResults(t, "text", r)
+		if strings.Contains(output, "NaN") || !strings.Contains(output, "Flesch Reading Ease Score: -") {
+			t.Errorf("%q: expected scores written as -, got\n%s", text, output)
+		}
+	}
+}
+
+func TestJSONSorted(t *testing.T) {
+	short := parseResult(t, "Education is important.", "short")
+	long := parseResult(t, "Education is important. Children learn quickly.", "long")
+	var buf bytes.Buffer
+	writer, err := newResultWriter("json", &buf, flesch.Formulas(), summaryOrder{key: "words", descending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Write(short)
+	writer.Write(long)
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Documents []result
+		Corpus    resultCorpus
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding: %s", err)
+	}
+	if decoded.Documents[0].Document != "long" || decoded.Corpus.Counts.Words != 9 {
+		t.Errorf("expected the longest document first and 9 words in all, got %s first and %d words",
+			decoded.Documents[0].Document, decoded.Corpus.Counts.Words)
+	}
+}
+
+func TestCSVChapters(t *testing.T) {
+	book := parseResult(t, "Call me Ishmael. Some years ago I went to sea.", "book.epub")
+	book.Title = "Moby Dick"
+	chapter := parseResult(t, "Call me Ishmael.", "book.epub/c1.xhtml")
+	chapter.Title = "Loomings"
+	book.Chapters = []result{chapter}
+
+	rows, err := csv.NewReader(strings.NewReader(writeResults(t, "csv", book))).ReadAll()
+	if err != nil {
+		t.Fatalf("reading csv: %s", err)
+	}
+	columns := make(map[string]int)
+	for i, column := range rows[0] {
+		columns[column] = i
+	}
+	expected := [][2]string{{"Moby Dick", ""}, {"Loomings", "book.epub"}}
+	for i, e := range expected {
+		row := rows[i+1]
+		if row[columns["title"]] != e[0] || row[columns["chapter_of"]] != e[1] {
+			t.Errorf("row %d: expected title %q of %q, got %q of %q",
+				i+1, e[0], e[1], row[columns["title"]], row[columns["chapter_of"]])
+		}
+	}
+}